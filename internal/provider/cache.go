@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ReadCache holds the permission and membership lists of parent objects for the lifetime of the
+// provider, which is a single Terraform run. Refreshing many child resources of the same parent
+// then reads the parent's list once instead of issuing one GET per child. The workspace memberships
+// and account emails, which memberships do not include, are kept as well so that they are read at most once.
+type ReadCache struct {
+	mu          sync.Mutex
+	lists       map[string]map[string]CachedChild
+	generations map[string]int
+	members     []WorkspaceMember
+	emails      map[string]string
+	group       singleflight.Group
+}
+
+// CachedChild is a permission or membership as returned by a list endpoint.
+type CachedChild struct {
+	Token         string `json:"token"`
+	Action        string `json:"action"`
+	AccessorType  string `json:"accessor_type"`
+	AccessorToken string `json:"accessor_token"`
+	MemberToken   string `json:"member_token"`
+}
+
+// NewReadCache returns an empty ReadCache.
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		lists:       map[string]map[string]CachedChild{},
+		generations: map[string]int{},
+		emails:      map[string]string{},
+	}
+}
+
+// Children returns the children listed at url under _embedded.<embeddedKey>, indexed by token.
+// Concurrent calls for the same url share a single request. A parent that no longer exists has no children.
+func (c *ReadCache) Children(ctx context.Context, client *http.Client, url string, embeddedKey string) (map[string]CachedChild, error) {
+	c.mu.Lock()
+	if children, ok := c.lists[url]; ok {
+		c.mu.Unlock()
+		return children, nil
+	}
+	generation := c.generations[url]
+	c.mu.Unlock()
+
+	result, err, _ := c.group.Do(url, func() (interface{}, error) {
+		children, err := fetchChildren(ctx, client, url, embeddedKey)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// A write since the fetch started makes the list stale, so it is returned but not kept
+		if c.generations[url] == generation {
+			c.lists[url] = children
+		}
+		return children, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]CachedChild), nil
+}
+
+// Invalidate drops the list cached for url. It is called after every write to one of its children.
+func (c *ReadCache) Invalidate(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.lists, url)
+	c.generations[url]++
+	c.group.Forget(url)
+}
+
+// Members returns every member of the workspace. A nil cache lists the memberships every time.
+// The provider does not change workspace memberships, so the list is kept for the whole run.
+func (c *ReadCache) Members(ctx context.Context, client *http.Client, modeHost string, workspaceId string) ([]WorkspaceMember, error) {
+	if c == nil {
+		return ListWorkspaceMembers(ctx, client, modeHost, workspaceId)
+	}
+
+	c.mu.Lock()
+	if c.members != nil {
+		members := c.members
+		c.mu.Unlock()
+		return members, nil
+	}
+	c.mu.Unlock()
+
+	result, err, _ := c.group.Do("members", func() (interface{}, error) {
+		members, err := ListWorkspaceMembers(ctx, client, modeHost, workspaceId)
+		if err != nil {
+			return nil, err
+		}
+		if members == nil {
+			members = []WorkspaceMember{}
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.members = members
+		return members, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]WorkspaceMember), nil
+}
+
+// AccountEmail returns the email address of the account with the given username. A nil cache reads the account every time.
+func (c *ReadCache) AccountEmail(ctx context.Context, client *http.Client, modeHost string, username string) (string, error) {
+	if c == nil {
+		return LookupAccountEmail(ctx, client, modeHost, username)
+	}
+
+	c.mu.Lock()
+	if email, ok := c.emails[username]; ok {
+		c.mu.Unlock()
+		return email, nil
+	}
+	c.mu.Unlock()
+
+	result, err, _ := c.group.Do("account:"+username, func() (interface{}, error) {
+		email, err := LookupAccountEmail(ctx, client, modeHost, username)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.emails[username] = email
+		return email, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+func fetchChildren(ctx context.Context, client *http.Client, url string, embeddedKey string) (map[string]CachedChild, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := HttpRetry(client, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return map[string]CachedChild{}, nil
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	var responseData struct {
+		Embedded map[string][]CachedChild `json:"_embedded"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&responseData); err != nil {
+		return nil, fmt.Errorf("error parsing response: %s", err)
+	}
+
+	children := map[string]CachedChild{}
+	for _, child := range responseData.Embedded[embeddedKey] {
+		children[child.Token] = child
+	}
+	return children, nil
+}
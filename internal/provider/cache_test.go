@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func testPermissions(tokens ...string) map[string]interface{} {
+	permissions := []CachedChild{}
+	for _, token := range tokens {
+		permissions = append(permissions, CachedChild{Token: token, Action: "view", AccessorType: "UserGroup", AccessorToken: "g-" + token})
+	}
+	return map[string]interface{}{"_embedded": map[string]interface{}{"permissions": permissions}}
+}
+
+func TestReadCacheChildren(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"GET /api/ws/spaces/c1/permissions": testPermissions("p1", "p2"),
+	})
+	cache := NewReadCache()
+	url := standIn.URL + "/api/ws/spaces/c1/permissions"
+
+	// Concurrent reads of the same parent share one request
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			children, err := cache.Children(ctx, http.DefaultClient, url, "permissions")
+			if err != nil {
+				t.Errorf("Children: %s", err)
+				return
+			}
+			if len(children) != 2 || children["p2"].AccessorToken != "g-p2" {
+				t.Errorf("Children = %v", children)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := standIn.count("GET", "/api/ws/spaces/c1/permissions"); got != 1 {
+		t.Errorf("permissions were listed %d times, want 1", got)
+	}
+
+	// A parent that does not exist has no children
+	children, err := cache.Children(ctx, http.DefaultClient, standIn.URL+"/api/ws/spaces/missing/permissions", "permissions")
+	if err != nil || len(children) != 0 {
+		t.Errorf("Children of a missing parent = %v, %v, want none", children, err)
+	}
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"GET /api/ws/spaces/c1/permissions": testPermissions("p1"),
+	})
+	cache := NewReadCache()
+	url := standIn.URL + "/api/ws/spaces/c1/permissions"
+
+	if _, err := cache.Children(ctx, http.DefaultClient, url, "permissions"); err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+
+	// A write to a child drops the list, so the next read sees the change
+	standIn.mu.Lock()
+	standIn.responses["GET /api/ws/spaces/c1/permissions"] = testPermissions("p1", "p3")
+	standIn.mu.Unlock()
+	cache.Invalidate(url)
+
+	children, err := cache.Children(ctx, http.DefaultClient, url, "permissions")
+	if err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+	if _, ok := children["p3"]; !ok {
+		t.Errorf("Children after Invalidate = %v, want p3", children)
+	}
+	if got := standIn.count("GET", "/api/ws/spaces/c1/permissions"); got != 2 {
+		t.Errorf("permissions were listed %d times, want 2", got)
+	}
+
+	// Other parents are not affected
+	cache.Invalidate(standIn.URL + "/api/ws/spaces/c2/permissions")
+	if _, err := cache.Children(ctx, http.DefaultClient, url, "permissions"); err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+	if got := standIn.count("GET", "/api/ws/spaces/c1/permissions"); got != 2 {
+		t.Errorf("permissions were listed %d times, want 2", got)
+	}
+}
+
+func TestReadCacheDropsListsFetchedDuringWrite(t *testing.T) {
+	ctx := context.Background()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			started <- struct{}{}
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(testPermissions("p1"))
+	}))
+	defer server.Close()
+	cache := NewReadCache()
+	url := server.URL + "/api/ws/spaces/c1/permissions"
+
+	// A write lands while the list is being fetched
+	done := make(chan error)
+	go func() {
+		_, err := cache.Children(ctx, http.DefaultClient, url, "permissions")
+		done <- err
+	}()
+	<-started
+	cache.Invalidate(url)
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+
+	// The list fetched before the write was not kept, so it is fetched again
+	if _, err := cache.Children(ctx, http.DefaultClient, url, "permissions"); err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 2 {
+		t.Errorf("permissions were listed %d times, want 2", requests)
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CurrentAccountDataSource{}
+
+func NewCurrentAccountDataSource() datasource.DataSource {
+	return &CurrentAccountDataSource{}
+}
+
+// CurrentAccountDataSource defines the data source implementation.
+type CurrentAccountDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type CurrentAccountDataSourceModel struct {
+	Username          types.String `tfsdk:"username"`
+	AccountToken      types.String `tfsdk:"account_token"`
+	Name              types.String `tfsdk:"name"`
+	Email             types.String `tfsdk:"email"`
+	Admin             types.Bool   `tfsdk:"admin"`
+	WorkspaceId       types.String `tfsdk:"workspace_id"`
+	WorkspaceName     types.String `tfsdk:"workspace_name"`
+	WorkspaceToken    types.String `tfsdk:"workspace_token"`
+	WorkspacePlanCode types.String `tfsdk:"workspace_plan_code"`
+}
+
+func (d *CurrentAccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_account"
+}
+
+func (d *CurrentAccountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Account the provider is authenticated as and the workspace it is configured for",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the authenticated account",
+				Computed:            true,
+			},
+			"account_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the authenticated account",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the authenticated account",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the authenticated account",
+				Computed:            true,
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authenticated account is an admin of the workspace",
+				Computed:            true,
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "Workspace ID the provider is configured for",
+				Computed:            true,
+			},
+			"workspace_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the workspace",
+				Computed:            true,
+			},
+			"workspace_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the workspace",
+				Computed:            true,
+			},
+			"workspace_plan_code": schema.StringAttribute{
+				MarkdownDescription: "Plan code of the workspace",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CurrentAccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *CurrentAccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CurrentAccountDataSourceModel
+
+	var accountData struct {
+		Username     string `json:"username"`
+		AccountToken string `json:"token"`
+		Name         string `json:"name"`
+		Email        string `json:"email"`
+	}
+	url := fmt.Sprintf("%s/api/account", d.modeHost)
+	if err := HttpGetJSON(ctx, d.client, url, &accountData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read current account, got error: %s", err))
+		return
+	}
+
+	var workspaceData struct {
+		Name           string `json:"name"`
+		WorkspaceToken string `json:"token"`
+		PlanCode       string `json:"plan_code"`
+	}
+	url = fmt.Sprintf("%s/api/%s", d.modeHost, d.workspaceId)
+	if err := HttpGetJSON(ctx, d.client, url, &workspaceData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace, got error: %s", err))
+		return
+	}
+
+	var membershipData struct {
+		Admin bool `json:"admin"`
+	}
+	url = fmt.Sprintf("%s/api/%s/memberships/%s", d.modeHost, d.workspaceId, accountData.Username)
+	if err := HttpGetJSON(ctx, d.client, url, &membershipData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace membership, got error: %s", err))
+		return
+	}
+
+	data.Username = types.StringValue(accountData.Username)
+	data.AccountToken = types.StringValue(accountData.AccountToken)
+	data.Name = types.StringValue(accountData.Name)
+	data.Email = types.StringValue(accountData.Email)
+	data.Admin = types.BoolValue(membershipData.Admin)
+	data.WorkspaceId = types.StringValue(d.workspaceId)
+	data.WorkspaceName = types.StringValue(workspaceData.Name)
+	data.WorkspaceToken = types.StringValue(workspaceData.WorkspaceToken)
+	data.WorkspacePlanCode = types.StringValue(workspaceData.PlanCode)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
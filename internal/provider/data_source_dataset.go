@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DatasetDataSource{}
+
+func NewDatasetDataSource() datasource.DataSource {
+	return &DatasetDataSource{}
+}
+
+// DatasetDataSource defines the data source implementation.
+type DatasetDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type DatasetColumnModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+type DatasetDataSourceModel struct {
+	DatasetToken    types.String         `tfsdk:"dataset_token"`
+	Name            types.String         `tfsdk:"name"`
+	Description     types.String         `tfsdk:"description"`
+	CollectionToken types.String         `tfsdk:"collection_token"`
+	DataSourceId    types.String         `tfsdk:"data_source_id"`
+	RawQuery        types.String         `tfsdk:"raw_query"`
+	RefreshCron     types.String         `tfsdk:"refresh_cron"`
+	RefreshTimeZone types.String         `tfsdk:"refresh_time_zone"`
+	State           types.String         `tfsdk:"state"`
+	CreatedAt       types.String         `tfsdk:"created_at"`
+	UpdatedAt       types.String         `tfsdk:"updated_at"`
+	Columns         []DatasetColumnModel `tfsdk:"columns"`
+}
+
+func (d *DatasetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset"
+}
+
+func (d *DatasetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Dataset data source",
+
+		Attributes: map[string]schema.Attribute{
+			"dataset_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the dataset",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the dataset",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the dataset",
+				Computed:            true,
+			},
+			"collection_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the collection the dataset is stored in",
+				Computed:            true,
+			},
+			"data_source_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the data source the dataset queries",
+				Computed:            true,
+			},
+			"raw_query": schema.StringAttribute{
+				MarkdownDescription: "SQL query producing the dataset",
+				Computed:            true,
+			},
+			"refresh_cron": schema.StringAttribute{
+				MarkdownDescription: "Cron expression of the refresh schedule",
+				Computed:            true,
+			},
+			"refresh_time_zone": schema.StringAttribute{
+				MarkdownDescription: "Time zone of the refresh schedule",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the dataset",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"updated_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "Columns of the dataset",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name": types.StringType,
+						"type": types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DatasetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *DatasetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DatasetDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/datasets/%s", d.modeHost, d.workspaceId, data.DatasetToken.ValueString())
+
+	var responseData datasetResponse
+	if err := HttpGetJSON(ctx, d.client, url, &responseData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return
+	}
+
+	var columnsData struct {
+		Embedded struct {
+			Columns []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"columns"`
+		} `json:"_embedded"`
+	}
+	if err := HttpGetJSON(ctx, d.client, url+"/columns", &columnsData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset columns, got error: %s", err))
+		return
+	}
+
+	// Assign the parsed values to the data model
+	data.Name = types.StringValue(responseData.Name)
+	data.Description = types.StringValue(responseData.Description)
+	data.CollectionToken = types.StringValue(responseData.CollectionToken)
+	data.DataSourceId = types.StringValue(responseData.DataSourceId)
+	data.RawQuery = types.StringValue(responseData.RawQuery)
+	data.State = types.StringValue(responseData.State)
+	data.CreatedAt = types.StringValue(responseData.CreatedAt)
+	data.UpdatedAt = types.StringValue(responseData.UpdatedAt)
+	data.RefreshCron = types.StringNull()
+	data.RefreshTimeZone = types.StringNull()
+	if responseData.RefreshSchedule != nil {
+		data.RefreshCron = types.StringValue(responseData.RefreshSchedule.Cron)
+		data.RefreshTimeZone = types.StringValue(responseData.RefreshSchedule.TimeZone)
+	}
+
+	data.Columns = []DatasetColumnModel{}
+	for _, column := range columnsData.Embedded.Columns {
+		data.Columns = append(data.Columns, DatasetColumnModel{
+			Name: types.StringValue(column.Name),
+			Type: types.StringValue(column.Type),
+		})
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EffectiveAccessDataSource{}
+
+func NewEffectiveAccessDataSource() datasource.DataSource {
+	return &EffectiveAccessDataSource{}
+}
+
+// EffectiveAccessDataSource defines the data source implementation.
+type EffectiveAccessDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type EffectiveAccessDataSourceModel struct {
+	MemberUsername types.String                `tfsdk:"member_username"`
+	GroupToken     types.String                `tfsdk:"group_token"`
+	ObjectToken    types.String                `tfsdk:"object_token"`
+	Entries        []EffectiveAccessEntryModel `tfsdk:"entries"`
+	Json           types.String                `tfsdk:"json"`
+	Csv            types.String                `tfsdk:"csv"`
+}
+
+type EffectiveAccessEntryModel struct {
+	MemberUsername types.String `tfsdk:"member_username"`
+	MemberToken    types.String `tfsdk:"member_token"`
+	ObjectType     types.String `tfsdk:"object_type"`
+	ObjectToken    types.String `tfsdk:"object_token"`
+	ObjectName     types.String `tfsdk:"object_name"`
+	Action         types.String `tfsdk:"action"`
+	Via            types.String `tfsdk:"via"`
+	GroupToken     types.String `tfsdk:"group_token"`
+}
+
+// effectiveAccessEntry is a row of the access matrix, also used for the JSON and CSV encodings.
+type effectiveAccessEntry struct {
+	MemberUsername string `json:"member_username"`
+	MemberToken    string `json:"member_token"`
+	ObjectType     string `json:"object_type"`
+	ObjectToken    string `json:"object_token"`
+	ObjectName     string `json:"object_name"`
+	Action         string `json:"action"`
+	Via            string `json:"via"`
+	GroupToken     string `json:"group_token"`
+}
+
+// effectiveAccessObject is a collection or data source whose permissions are expanded.
+type effectiveAccessObject struct {
+	objectType  string
+	token       string
+	name        string
+	listURL     string
+	embeddedKey string
+	// defaultAction is granted to every member of the workspace, as with the default access level of collections
+	defaultAction string
+}
+
+func (d *EffectiveAccessDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_access"
+}
+
+func (d *EffectiveAccessDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Which collections and data sources every active workspace member can reach, directly, through a group " +
+			"or through the default access level of a collection. Joins workspace memberships, group memberships, collection and data source permissions",
+
+		Attributes: map[string]schema.Attribute{
+			"member_username": schema.StringAttribute{
+				MarkdownDescription: "Only return the access of this member",
+				Optional:            true,
+			},
+			"group_token": schema.StringAttribute{
+				MarkdownDescription: "Only return access granted through this group",
+				Optional:            true,
+			},
+			"object_token": schema.StringAttribute{
+				MarkdownDescription: "Only return access to this collection or data source",
+				Optional:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "One entry per member, object and way the access is granted",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"member_username": schema.StringAttribute{
+							Computed: true,
+						},
+						"member_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "`collection` or `data_source`",
+							Computed:            true,
+						},
+						"object_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"object_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"action": schema.StringAttribute{
+							Computed: true,
+						},
+						"via": schema.StringAttribute{
+							MarkdownDescription: "`direct`, `group` or `default_access_level`",
+							Computed:            true,
+						},
+						"group_token": schema.StringAttribute{
+							MarkdownDescription: "Token of the group granting the access when `via` is `group`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "Entries encoded as a JSON array",
+				Computed:            true,
+			},
+			"csv": schema.StringAttribute{
+				MarkdownDescription: "Entries encoded as CSV with a header row",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EffectiveAccessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *EffectiveAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectiveAccessDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := ListWorkspaceMembers(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list memberships: %s", err))
+		return
+	}
+	activeMembers := map[string]WorkspaceMember{}
+	for _, member := range members {
+		if member.State != "deactivated" {
+			activeMembers[member.MemberToken] = member
+		}
+	}
+
+	groupMembers, err := listGroupMembers(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups: %s", err))
+		return
+	}
+
+	objects, err := listAccessObjects(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list collections and data sources: %s", err))
+		return
+	}
+
+	entries := []effectiveAccessEntry{}
+	for _, object := range objects {
+		if !data.ObjectToken.IsNull() && data.ObjectToken.ValueString() != object.token {
+			continue
+		}
+
+		add := func(member WorkspaceMember, action string, via string, groupToken string) {
+			entries = append(entries, effectiveAccessEntry{
+				MemberUsername: member.MemberUsername,
+				MemberToken:    member.MemberToken,
+				ObjectType:     object.objectType,
+				ObjectToken:    object.token,
+				ObjectName:     object.name,
+				Action:         action,
+				Via:            via,
+				GroupToken:     groupToken,
+			})
+		}
+
+		if object.defaultAction != "" {
+			for _, member := range activeMembers {
+				add(member, object.defaultAction, "default_access_level", "")
+			}
+		}
+
+		permissions, err := fetchChildren(ctx, d.client, object.listURL, object.embeddedKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions of %s %s: %s", object.objectType, object.token, err))
+			return
+		}
+		for _, permission := range permissions {
+			switch permission.AccessorType {
+			case "Account":
+				if member, ok := activeMembers[permission.AccessorToken]; ok {
+					add(member, permission.Action, "direct", "")
+				}
+			case "UserGroup":
+				for _, memberToken := range groupMembers[permission.AccessorToken] {
+					if member, ok := activeMembers[memberToken]; ok {
+						add(member, permission.Action, "group", permission.AccessorToken)
+					}
+				}
+			}
+		}
+	}
+
+	filtered := []effectiveAccessEntry{}
+	for _, entry := range entries {
+		if !data.MemberUsername.IsNull() && entry.MemberUsername != data.MemberUsername.ValueString() {
+			continue
+		}
+		if !data.GroupToken.IsNull() && entry.GroupToken != data.GroupToken.ValueString() {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].MemberUsername != filtered[j].MemberUsername {
+			return filtered[i].MemberUsername < filtered[j].MemberUsername
+		}
+		if filtered[i].ObjectType != filtered[j].ObjectType {
+			return filtered[i].ObjectType < filtered[j].ObjectType
+		}
+		if filtered[i].ObjectToken != filtered[j].ObjectToken {
+			return filtered[i].ObjectToken < filtered[j].ObjectToken
+		}
+		return filtered[i].Via+filtered[i].GroupToken < filtered[j].Via+filtered[j].GroupToken
+	})
+
+	data.Entries = []EffectiveAccessEntryModel{}
+	for _, entry := range filtered {
+		data.Entries = append(data.Entries, EffectiveAccessEntryModel{
+			MemberUsername: types.StringValue(entry.MemberUsername),
+			MemberToken:    types.StringValue(entry.MemberToken),
+			ObjectType:     types.StringValue(entry.ObjectType),
+			ObjectToken:    types.StringValue(entry.ObjectToken),
+			ObjectName:     types.StringValue(entry.ObjectName),
+			Action:         types.StringValue(entry.Action),
+			Via:            types.StringValue(entry.Via),
+			GroupToken:     types.StringValue(entry.GroupToken),
+		})
+	}
+
+	jsonBody, _ := json.Marshal(filtered)
+	data.Json = types.StringValue(string(jsonBody))
+
+	var csvBody bytes.Buffer
+	writer := csv.NewWriter(&csvBody)
+	_ = writer.Write([]string{"member_username", "member_token", "object_type", "object_token", "object_name", "action", "via", "group_token"})
+	for _, entry := range filtered {
+		_ = writer.Write([]string{entry.MemberUsername, entry.MemberToken, entry.ObjectType, entry.ObjectToken, entry.ObjectName, entry.Action, entry.Via, entry.GroupToken})
+	}
+	writer.Flush()
+	data.Csv = types.StringValue(csvBody.String())
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listGroupMembers returns the member tokens of every group that is not soft-deleted, by group token.
+// Groups without members are included with an empty list.
+func listGroupMembers(ctx context.Context, client *http.Client, modeHost string, workspaceId string) (map[string][]string, error) {
+	var groupsData struct {
+		Embedded struct {
+			Groups []struct {
+				GroupToken string `json:"token"`
+				State      string `json:"state"`
+			} `json:"groups"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/groups", modeHost, workspaceId)
+	if err := HttpGetJSON(ctx, client, url, &groupsData); err != nil {
+		return nil, err
+	}
+
+	groupMembers := map[string][]string{}
+	for _, group := range groupsData.Embedded.Groups {
+		if group.State == "soft_deleted" {
+			continue
+		}
+		url := fmt.Sprintf("%s/api/%s/groups/%s/memberships", modeHost, workspaceId, group.GroupToken)
+		memberships, err := fetchChildren(ctx, client, url, "group_memberships")
+		if err != nil {
+			return nil, err
+		}
+		groupMembers[group.GroupToken] = []string{}
+		for _, membership := range memberships {
+			groupMembers[group.GroupToken] = append(groupMembers[group.GroupToken], membership.MemberToken)
+		}
+	}
+	return groupMembers, nil
+}
+
+// listAccessObjects returns the collections and data sources of the workspace.
+func listAccessObjects(ctx context.Context, client *http.Client, modeHost string, workspaceId string) ([]effectiveAccessObject, error) {
+	var collectionsData struct {
+		Embedded struct {
+			Spaces []struct {
+				CollectionToken    string `json:"token"`
+				Name               string `json:"name"`
+				State              string `json:"state"`
+				DefaultAccessLevel string `json:"default_access_level"`
+			} `json:"spaces"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/spaces?filter=all", modeHost, workspaceId)
+	if err := HttpGetJSON(ctx, client, url, &collectionsData); err != nil {
+		return nil, err
+	}
+
+	objects := []effectiveAccessObject{}
+	for _, collection := range collectionsData.Embedded.Spaces {
+		if collection.State == "soft_deleted" {
+			continue
+		}
+		object := effectiveAccessObject{
+			objectType:  "collection",
+			token:       collection.CollectionToken,
+			name:        collection.Name,
+			listURL:     fmt.Sprintf("%s/api/%s/spaces/%s/permissions", modeHost, workspaceId, collection.CollectionToken),
+			embeddedKey: "permissions",
+		}
+		if collection.DefaultAccessLevel != "none" && collection.DefaultAccessLevel != "restricted" {
+			object.defaultAction = collection.DefaultAccessLevel
+		}
+		objects = append(objects, object)
+	}
+
+	var dataSourcesData struct {
+		Embedded struct {
+			DataSources []struct {
+				DataSourceToken string `json:"token"`
+				Name            string `json:"name"`
+				SoftDeleted     bool   `json:"soft_deleted"`
+			} `json:"data_sources"`
+		} `json:"_embedded"`
+	}
+	url = fmt.Sprintf("%s/api/%s/data_sources", modeHost, workspaceId)
+	if err := HttpGetJSON(ctx, client, url, &dataSourcesData); err != nil {
+		return nil, err
+	}
+
+	for _, dataSource := range dataSourcesData.Embedded.DataSources {
+		if dataSource.SoftDeleted {
+			continue
+		}
+		objects = append(objects, effectiveAccessObject{
+			objectType:  "data_source",
+			token:       dataSource.DataSourceToken,
+			name:        dataSource.Name,
+			listURL:     fmt.Sprintf("%s/api/%s/data_sources/%s/permissions", modeHost, workspaceId, dataSource.DataSourceToken),
+			embeddedKey: "data_source_entitlements",
+		})
+	}
+	return objects, nil
+}
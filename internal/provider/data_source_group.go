@@ -26,6 +26,13 @@ type GroupDataSource struct {
 	workspaceId string
 }
 
+// GroupModel describes a group as exposed by the group data sources.
+type GroupModel struct {
+	GroupToken types.String `tfsdk:"group_token"`
+	Name       types.String `tfsdk:"name"`
+	State      types.String `tfsdk:"state"`
+}
+
 func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
@@ -78,7 +85,7 @@ func (d *GroupDataSource) Configure(ctx context.Context, req datasource.Configur
 }
 
 func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data GroupResourceModel
+	var data GroupModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -26,7 +26,7 @@ type GroupsDataSource struct {
 }
 
 type GroupsDataSourceModel struct {
-	Groups []GroupResourceModel `tfsdk:"groups"`
+	Groups []GroupModel `tfsdk:"groups"`
 }
 
 func (d *GroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -121,10 +121,10 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	data.Groups = []GroupResourceModel{}
+	data.Groups = []GroupModel{}
 
 	for _, group := range responseData.Embedded.Groups {
-		data.Groups = append(data.Groups, GroupResourceModel{
+		data.Groups = append(data.Groups, GroupModel{
 			GroupToken: types.StringValue(group.GroupToken),
 			Name:       types.StringValue(group.Name),
 			State:      types.StringValue(group.State),
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MemberDataSource{}
+
+func NewMemberDataSource() datasource.DataSource {
+	return &MemberDataSource{}
+}
+
+// MemberDataSource defines the data source implementation.
+type MemberDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type MemberDataSourceModel struct {
+	Username    types.String `tfsdk:"username"`
+	Email       types.String `tfsdk:"email"`
+	MemberToken types.String `tfsdk:"member_token"`
+	Admin       types.Bool   `tfsdk:"admin"`
+	State       types.String `tfsdk:"state"`
+	ActivatedAt types.String `tfsdk:"activated_at"`
+}
+
+func (d *MemberDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_member"
+}
+
+func (d *MemberDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up a workspace member by username or email",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the member",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("email")),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the member",
+				Optional:            true,
+				Computed:            true,
+			},
+			"member_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the member",
+				Computed:            true,
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the member is a workspace admin",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the membership",
+				Computed:            true,
+			},
+			"activated_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *MemberDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *MemberDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MemberDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := ListWorkspaceMembers(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list memberships: %s", err))
+		return
+	}
+
+	var found *WorkspaceMember
+	email := ""
+	if !data.Username.IsNull() {
+		for i, member := range members {
+			if strings.EqualFold(member.MemberUsername, data.Username.ValueString()) {
+				found = &members[i]
+				break
+			}
+		}
+	} else {
+		matched, err := MatchMemberEmails(ctx, nil, d.client, d.modeHost, members, []string{data.Email.ValueString()}, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", err.Error())
+			return
+		}
+		if member, ok := matched[strings.ToLower(data.Email.ValueString())]; ok {
+			found = &member
+			email = data.Email.ValueString()
+		}
+	}
+
+	if found == nil {
+		resp.Diagnostics.AddError("Member Not Found", "No workspace member matches the given username or email")
+		return
+	}
+
+	if email == "" {
+		email, err = LookupAccountEmail(ctx, d.client, d.modeHost, found.MemberUsername)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read account %s: %s", found.MemberUsername, err))
+			return
+		}
+	}
+
+	data.Username = types.StringValue(found.MemberUsername)
+	data.Email = types.StringValue(email)
+	data.MemberToken = types.StringValue(found.MemberToken)
+	data.Admin = types.BoolValue(found.Admin)
+	data.State = types.StringValue(found.State)
+	data.ActivatedAt = types.StringValue(found.ActivatedAt)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &QueryRunResultsDataSource{}
+
+const (
+	defaultQueryRunResultsMaxRows  = 1000
+	defaultQueryRunResultsMaxBytes = 1024 * 1024
+)
+
+func NewQueryRunResultsDataSource() datasource.DataSource {
+	return &QueryRunResultsDataSource{}
+}
+
+// QueryRunResultsDataSource defines the data source implementation.
+type QueryRunResultsDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type QueryRunResultsDataSourceModel struct {
+	ReportToken   types.String `tfsdk:"report_token"`
+	RunToken      types.String `tfsdk:"run_token"`
+	QueryToken    types.String `tfsdk:"query_token"`
+	MaxRows       types.Int64  `tfsdk:"max_rows"`
+	MaxBytes      types.Int64  `tfsdk:"max_bytes"`
+	QueryRunToken types.String `tfsdk:"query_run_token"`
+	Csv           types.String `tfsdk:"csv"`
+	Columns       types.List   `tfsdk:"columns"`
+	Rows          types.List   `tfsdk:"rows"`
+	RowCount      types.Int64  `tfsdk:"row_count"`
+}
+
+func (d *QueryRunResultsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_run_results"
+}
+
+func (d *QueryRunResultsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Result set of a query run, as raw CSV and as a list of rows",
+
+		Attributes: map[string]schema.Attribute{
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report",
+				Required:            true,
+			},
+			"run_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report run. Defaults to the latest successful run of the report",
+				Optional:            true,
+				Computed:            true,
+			},
+			"query_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the query whose results are returned. Required when the report has more than one query",
+				Optional:            true,
+				Computed:            true,
+			},
+			"max_rows": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of rows the result set may contain. Defaults to %d", defaultQueryRunResultsMaxRows),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_bytes": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum size of the CSV content in bytes. Defaults to %d", defaultQueryRunResultsMaxBytes),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"query_run_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the query run the results belong to",
+				Computed:            true,
+			},
+			"csv": schema.StringAttribute{
+				MarkdownDescription: "Raw CSV content of the result set",
+				Computed:            true,
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "Column names of the result set",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "Rows of the result set, keyed by column name",
+				Computed:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+			},
+			"row_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of rows in the result set",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *QueryRunResultsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *QueryRunResultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QueryRunResultsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxRows := int64(defaultQueryRunResultsMaxRows)
+	if !data.MaxRows.IsNull() {
+		maxRows = data.MaxRows.ValueInt64()
+	}
+	maxBytes := int64(defaultQueryRunResultsMaxBytes)
+	if !data.MaxBytes.IsNull() {
+		maxBytes = data.MaxBytes.ValueInt64()
+	}
+
+	reportToken := data.ReportToken.ValueString()
+
+	// Without an explicit run, use the latest successful run of the report
+	runToken := data.RunToken.ValueString()
+	if data.RunToken.IsNull() {
+		var reportData struct {
+			LastSuccessfulRunToken string `json:"last_successful_run_token"`
+		}
+		url := fmt.Sprintf("%s/api/%s/reports/%s", d.modeHost, d.workspaceId, reportToken)
+		if err := HttpGetJSON(ctx, d.client, url, &reportData); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+			return
+		}
+		if reportData.LastSuccessfulRunToken == "" {
+			resp.Diagnostics.AddError("No Report Run", fmt.Sprintf("Report %s has no successful run", reportToken))
+			return
+		}
+		runToken = reportData.LastSuccessfulRunToken
+	}
+
+	var queryRunsData struct {
+		Embedded struct {
+			QueryRuns []struct {
+				QueryRunToken string `json:"token"`
+				QueryToken    string `json:"query_token"`
+				State         string `json:"state"`
+			} `json:"query_runs"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/reports/%s/runs/%s/query_runs", d.modeHost, d.workspaceId, reportToken, runToken)
+	if err := HttpGetJSON(ctx, d.client, url, &queryRunsData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query runs, got error: %s", err))
+		return
+	}
+
+	queryRuns := queryRunsData.Embedded.QueryRuns
+	queryRunToken := ""
+	queryToken := data.QueryToken.ValueString()
+	if data.QueryToken.IsNull() {
+		if len(queryRuns) != 1 {
+			resp.Diagnostics.AddError("Ambiguous Query", fmt.Sprintf("Report run %s has %d query runs, set query_token to select one", runToken, len(queryRuns)))
+			return
+		}
+		queryRunToken = queryRuns[0].QueryRunToken
+		queryToken = queryRuns[0].QueryToken
+	} else {
+		for _, queryRun := range queryRuns {
+			if queryRun.QueryToken == queryToken {
+				queryRunToken = queryRun.QueryRunToken
+				break
+			}
+		}
+		if queryRunToken == "" {
+			resp.Diagnostics.AddError("Query Run Not Found", fmt.Sprintf("Report run %s has no run of query %s", runToken, queryToken))
+			return
+		}
+	}
+
+	url = fmt.Sprintf("%s/api/%s/reports/%s/runs/%s/query_runs/%s/results/content.csv", d.modeHost, d.workspaceId, reportToken, runToken, queryRunToken)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query run results: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(d.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read query run results: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unexpected status code: %d", httpResp.StatusCode))
+		return
+	}
+
+	// Read one byte past the cap so oversized results are detected without loading them entirely
+	content, err := io.ReadAll(io.LimitReader(httpResp.Body, maxBytes+1))
+	if err != nil {
+		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Unable to read response body: %s", err))
+		return
+	}
+	if int64(len(content)) > maxBytes {
+		resp.Diagnostics.AddError("Result Too Large", fmt.Sprintf("Result set of query run %s exceeds max_bytes (%d)", queryRunToken, maxBytes))
+		return
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		resp.Diagnostics.AddError("Decode Error", fmt.Sprintf("Error parsing CSV content: %s", err))
+		return
+	}
+
+	columns := []string{}
+	rows := []map[string]string{}
+	if len(records) > 0 {
+		columns = records[0]
+		for _, record := range records[1:] {
+			row := map[string]string{}
+			for i, column := range columns {
+				if i < len(record) {
+					row[column] = record[i]
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	if int64(len(rows)) > maxRows {
+		resp.Diagnostics.AddError("Result Too Large", fmt.Sprintf("Result set of query run %s has %d rows, more than max_rows (%d)", queryRunToken, len(rows), maxRows))
+		return
+	}
+
+	data.RunToken = types.StringValue(runToken)
+	data.QueryToken = types.StringValue(queryToken)
+	data.QueryRunToken = types.StringValue(queryRunToken)
+	data.Csv = types.StringValue(string(content))
+	data.RowCount = types.Int64Value(int64(len(rows)))
+
+	columnsList, diags := types.ListValueFrom(ctx, types.StringType, columns)
+	resp.Diagnostics.Append(diags...)
+	rowsList, diags := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, rows)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Columns = columnsList
+	data.Rows = rowsList
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &ReportRunsDataSource{}
+
+const defaultReportRunsLimit = 20
+
+func NewReportRunsDataSource() datasource.DataSource {
+	return &ReportRunsDataSource{}
+}
+
+type ReportRunsDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type ReportRunModel struct {
+	RunToken        types.String `tfsdk:"run_token"`
+	State           types.String `tfsdk:"state"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	CompletedAt     types.String `tfsdk:"completed_at"`
+	DurationSeconds types.Int64  `tfsdk:"duration_seconds"`
+	TriggeredBy     types.String `tfsdk:"triggered_by"`
+	Scheduled       types.Bool   `tfsdk:"scheduled"`
+}
+
+type ReportRunsDataSourceModel struct {
+	ReportToken                   types.String     `tfsdk:"report_token"`
+	Limit                         types.Int64      `tfsdk:"limit"`
+	MaxAge                        types.String     `tfsdk:"max_age"`
+	Runs                          []ReportRunModel `tfsdk:"runs"`
+	LatestRunState                types.String     `tfsdk:"latest_run_state"`
+	LatestSuccessfulRunToken      types.String     `tfsdk:"latest_successful_run_token"`
+	LatestSuccessfulRunAt         types.String     `tfsdk:"latest_successful_run_at"`
+	LatestSuccessfulRunAgeSeconds types.Int64      `tfsdk:"latest_successful_run_age_seconds"`
+	Stale                         types.Bool       `tfsdk:"stale"`
+}
+
+func (d *ReportRunsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_runs"
+}
+
+func (d *ReportRunsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Recent run history of a report",
+
+		Attributes: map[string]schema.Attribute{
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report",
+				Required:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of runs to return, newest first. Defaults to %d", defaultReportRunsLimit),
+				Optional:            true,
+			},
+			"max_age": schema.StringAttribute{
+				MarkdownDescription: "Maximum age of the latest successful run, as a Go duration string such as `24h`. Used to compute `stale`",
+				Optional:            true,
+				Validators: []validator.String{
+					DurationValidator(),
+				},
+			},
+			"runs": schema.ListAttribute{
+				MarkdownDescription: "List of report runs, newest first",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"run_token":        types.StringType,
+						"state":            types.StringType,
+						"created_at":       types.StringType,
+						"completed_at":     types.StringType,
+						"duration_seconds": types.Int64Type,
+						"triggered_by":     types.StringType,
+						"scheduled":        types.BoolType,
+					},
+				},
+			},
+			"latest_run_state": schema.StringAttribute{
+				MarkdownDescription: "State of the most recent run",
+				Computed:            true,
+			},
+			"latest_successful_run_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the most recent successful run",
+				Computed:            true,
+			},
+			"latest_successful_run_at": schema.StringAttribute{
+				MarkdownDescription: "Completion time of the most recent successful run",
+				Computed:            true,
+			},
+			"latest_successful_run_age_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds since the most recent successful run completed",
+				Computed:            true,
+			},
+			"stale": schema.BoolAttribute{
+				MarkdownDescription: "Whether there is no successful run younger than `max_age`. Always false when `max_age` is not set",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ReportRunsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *ReportRunsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ReportRunsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultReportRunsLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s/runs", d.modeHost, d.workspaceId, data.ReportToken.ValueString())
+
+	var responseData struct {
+		Embedded struct {
+			ReportRuns []struct {
+				RunToken    string `json:"token"`
+				State       string `json:"state"`
+				CreatedAt   string `json:"created_at"`
+				CompletedAt string `json:"completed_at"`
+				Links       struct {
+					ExecutedBy struct {
+						Href string `json:"href"`
+					} `json:"executed_by"`
+					ReportSchedule struct {
+						Href string `json:"href"`
+					} `json:"report_schedule"`
+				} `json:"_links"`
+			} `json:"report_runs"`
+		} `json:"_embedded"`
+	}
+
+	if err := HttpGetJSON(ctx, d.client, url, &responseData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list report runs: %s", err))
+		return
+	}
+
+	runs := responseData.Embedded.ReportRuns
+	sort.SliceStable(runs, func(i, j int) bool {
+		return parseModeTime(runs[i].CreatedAt).After(parseModeTime(runs[j].CreatedAt))
+	})
+
+	data.Runs = []ReportRunModel{}
+	data.LatestRunState = types.StringNull()
+	data.LatestSuccessfulRunToken = types.StringNull()
+	data.LatestSuccessfulRunAt = types.StringNull()
+	data.LatestSuccessfulRunAgeSeconds = types.Int64Null()
+
+	var latestSuccess time.Time
+	for i, run := range runs {
+		if i == 0 {
+			data.LatestRunState = types.StringValue(run.State)
+		}
+
+		createdAt := parseModeTime(run.CreatedAt)
+		completedAt := parseModeTime(run.CompletedAt)
+
+		duration := types.Int64Null()
+		if !createdAt.IsZero() && !completedAt.IsZero() {
+			duration = types.Int64Value(int64(completedAt.Sub(createdAt).Seconds()))
+		}
+
+		if (run.State == "succeeded" || run.State == "completed") && latestSuccess.IsZero() && !completedAt.IsZero() {
+			latestSuccess = completedAt
+			data.LatestSuccessfulRunToken = types.StringValue(run.RunToken)
+			data.LatestSuccessfulRunAt = types.StringValue(run.CompletedAt)
+			data.LatestSuccessfulRunAgeSeconds = types.Int64Value(int64(time.Since(completedAt).Seconds()))
+		}
+
+		if int64(i) < limit {
+			executedBy := run.Links.ExecutedBy.Href
+			data.Runs = append(data.Runs, ReportRunModel{
+				RunToken:        types.StringValue(run.RunToken),
+				State:           types.StringValue(run.State),
+				CreatedAt:       types.StringValue(run.CreatedAt),
+				CompletedAt:     types.StringValue(run.CompletedAt),
+				DurationSeconds: duration,
+				TriggeredBy:     types.StringValue(executedBy[strings.LastIndex(executedBy, "/")+1:]),
+				Scheduled:       types.BoolValue(run.Links.ReportSchedule.Href != ""),
+			})
+		}
+	}
+
+	data.Stale = types.BoolValue(false)
+	if !data.MaxAge.IsNull() {
+		maxAge, err := time.ParseDuration(data.MaxAge.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Max Age", fmt.Sprintf("Unable to parse max_age %q: %s", data.MaxAge.ValueString(), err))
+			return
+		}
+		data.Stale = types.BoolValue(latestSuccess.IsZero() || time.Since(latestSuccess) > maxAge)
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseModeTime parses an API timestamp, returning the zero time for empty or malformed values.
+func parseModeTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
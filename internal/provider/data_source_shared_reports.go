@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SharedReportsDataSource{}
+
+func NewSharedReportsDataSource() datasource.DataSource {
+	return &SharedReportsDataSource{}
+}
+
+// SharedReportsDataSource defines the data source implementation.
+type SharedReportsDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type SharedReportsDataSourceModel struct {
+	Reports []SharedReportModel `tfsdk:"reports"`
+	Count   types.Int64         `tfsdk:"count"`
+}
+
+type SharedReportModel struct {
+	ReportToken            types.String `tfsdk:"report_token"`
+	Name                   types.String `tfsdk:"name"`
+	CollectionToken        types.String `tfsdk:"collection_token"`
+	PublicLinkEnabled      types.Bool   `tfsdk:"public_link_enabled"`
+	ExternalSharingEnabled types.Bool   `tfsdk:"external_sharing_enabled"`
+	EmbedAllowed           types.Bool   `tfsdk:"embed_allowed"`
+	ViewableByEveryone     types.Bool   `tfsdk:"viewable_by_everyone"`
+}
+
+func (d *SharedReportsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shared_reports"
+}
+
+func (d *SharedReportsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the reports of the workspace that are shared beyond their collection, through a public link, external sharing, " +
+			"embedding or being viewable by every member. " +
+			"Use `count` in a `check` block to be alerted about them",
+
+		Attributes: map[string]schema.Attribute{
+			"reports": schema.ListNestedAttribute{
+				MarkdownDescription: "Shared reports",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"report_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"collection_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"public_link_enabled": schema.BoolAttribute{
+							Computed: true,
+						},
+						"external_sharing_enabled": schema.BoolAttribute{
+							Computed: true,
+						},
+						"embed_allowed": schema.BoolAttribute{
+							Computed: true,
+						},
+						"viewable_by_everyone": schema.BoolAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: "Number of shared reports",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SharedReportsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *SharedReportsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SharedReportsDataSourceModel
+
+	var collectionsData struct {
+		Embedded struct {
+			Spaces []struct {
+				CollectionToken string `json:"token"`
+				State           string `json:"state"`
+			} `json:"spaces"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/spaces?filter=all", d.modeHost, d.workspaceId)
+	if err := HttpGetJSON(ctx, d.client, url, &collectionsData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list collections: %s", err))
+		return
+	}
+
+	data.Reports = []SharedReportModel{}
+	for _, collection := range collectionsData.Embedded.Spaces {
+		if collection.State == "soft_deleted" {
+			continue
+		}
+
+		var reportsData struct {
+			Embedded struct {
+				Reports []struct {
+					ReportToken string `json:"token"`
+					Name        string `json:"name"`
+					ReportSharing
+				} `json:"reports"`
+			} `json:"_embedded"`
+		}
+		url := fmt.Sprintf("%s/api/%s/spaces/%s/reports", d.modeHost, d.workspaceId, collection.CollectionToken)
+		if err := HttpGetJSON(ctx, d.client, url, &reportsData); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list reports of collection %s: %s", collection.CollectionToken, err))
+			return
+		}
+
+		for _, report := range reportsData.Embedded.Reports {
+			if !report.PublicLinkEnabled && !report.ExternalSharingEnabled && !report.EmbedAllowed && !report.ViewableByEveryone {
+				continue
+			}
+			data.Reports = append(data.Reports, SharedReportModel{
+				ReportToken:            types.StringValue(report.ReportToken),
+				Name:                   types.StringValue(report.Name),
+				CollectionToken:        types.StringValue(collection.CollectionToken),
+				PublicLinkEnabled:      types.BoolValue(report.PublicLinkEnabled),
+				ExternalSharingEnabled: types.BoolValue(report.ExternalSharingEnabled),
+				EmbedAllowed:           types.BoolValue(report.EmbedAllowed),
+				ViewableByEveryone:     types.BoolValue(report.ViewableByEveryone),
+			})
+		}
+	}
+
+	sort.Slice(data.Reports, func(i, j int) bool {
+		return data.Reports[i].ReportToken.ValueString() < data.Reports[j].ReportToken.ValueString()
+	})
+	data.Count = types.Int64Value(int64(len(data.Reports)))
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkspaceHygieneDataSource{}
+
+func NewWorkspaceHygieneDataSource() datasource.DataSource {
+	return &WorkspaceHygieneDataSource{}
+}
+
+// WorkspaceHygieneDataSource defines the data source implementation.
+type WorkspaceHygieneDataSource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+}
+
+type WorkspaceHygieneDataSourceModel struct {
+	EmptyGroups                   []types.String                     `tfsdk:"empty_groups"`
+	CollectionsWithoutPermissions []types.String                     `tfsdk:"collections_without_permissions"`
+	DeactivatedMemberPermissions  []DeactivatedMemberPermissionModel `tfsdk:"deactivated_member_permissions"`
+	AsleepDataSources             []types.String                     `tfsdk:"asleep_data_sources"`
+	UnqueryableDataSources        []types.String                     `tfsdk:"unqueryable_data_sources"`
+	UngroupedMembers              []types.String                     `tfsdk:"ungrouped_members"`
+	IssueCount                    types.Int64                        `tfsdk:"issue_count"`
+}
+
+type DeactivatedMemberPermissionModel struct {
+	ObjectType      types.String `tfsdk:"object_type"`
+	ObjectToken     types.String `tfsdk:"object_token"`
+	PermissionToken types.String `tfsdk:"permission_token"`
+	MemberUsername  types.String `tfsdk:"member_username"`
+	MemberToken     types.String `tfsdk:"member_token"`
+}
+
+func (d *WorkspaceHygieneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace_hygiene"
+}
+
+func (d *WorkspaceHygieneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Flags cruft in the workspace, such as empty groups and permissions of deactivated members. " +
+			"Use `issue_count` in a `check` block to be warned when it accumulates",
+
+		Attributes: map[string]schema.Attribute{
+			"empty_groups": schema.ListAttribute{
+				MarkdownDescription: "Tokens of groups without members",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"collections_without_permissions": schema.ListAttribute{
+				MarkdownDescription: "Tokens of collections without explicit permissions",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"deactivated_member_permissions": schema.ListNestedAttribute{
+				MarkdownDescription: "Collection and data source permissions granted directly to deactivated members",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "`collection` or `data_source`",
+							Computed:            true,
+						},
+						"object_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"permission_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"member_username": schema.StringAttribute{
+							Computed: true,
+						},
+						"member_token": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"asleep_data_sources": schema.ListAttribute{
+				MarkdownDescription: "Tokens of data sources that are asleep",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"unqueryable_data_sources": schema.ListAttribute{
+				MarkdownDescription: "Tokens of data sources that are not queryable",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ungrouped_members": schema.ListAttribute{
+				MarkdownDescription: "Usernames of active members that belong to no group",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"issue_count": schema.Int64Attribute{
+				MarkdownDescription: "Total number of flagged items",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WorkspaceHygieneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = config.Client
+	d.modeHost = config.ModeHost
+	d.workspaceId = config.WorkspaceId
+}
+
+func (d *WorkspaceHygieneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkspaceHygieneDataSourceModel
+
+	members, err := ListWorkspaceMembers(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list memberships: %s", err))
+		return
+	}
+	membersByToken := map[string]WorkspaceMember{}
+	for _, member := range members {
+		membersByToken[member.MemberToken] = member
+	}
+
+	groupMembers, err := listGroupMembers(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups: %s", err))
+		return
+	}
+
+	grouped := map[string]bool{}
+	emptyGroups := []string{}
+	for groupToken, memberTokens := range groupMembers {
+		if len(memberTokens) == 0 {
+			emptyGroups = append(emptyGroups, groupToken)
+		}
+		for _, memberToken := range memberTokens {
+			grouped[memberToken] = true
+		}
+	}
+
+	ungroupedMembers := []string{}
+	for _, member := range members {
+		if member.State != "deactivated" && !grouped[member.MemberToken] {
+			ungroupedMembers = append(ungroupedMembers, member.MemberUsername)
+		}
+	}
+
+	objects, err := listAccessObjects(ctx, d.client, d.modeHost, d.workspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list collections and data sources: %s", err))
+		return
+	}
+
+	collectionsWithoutPermissions := []string{}
+	data.DeactivatedMemberPermissions = []DeactivatedMemberPermissionModel{}
+	for _, object := range objects {
+		permissions, err := fetchChildren(ctx, d.client, object.listURL, object.embeddedKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list permissions of %s %s: %s", object.objectType, object.token, err))
+			return
+		}
+		if object.objectType == "collection" && len(permissions) == 0 {
+			collectionsWithoutPermissions = append(collectionsWithoutPermissions, object.token)
+		}
+
+		for _, permission := range permissions {
+			member, ok := membersByToken[permission.AccessorToken]
+			if permission.AccessorType != "Account" || !ok || member.State != "deactivated" {
+				continue
+			}
+			data.DeactivatedMemberPermissions = append(data.DeactivatedMemberPermissions, DeactivatedMemberPermissionModel{
+				ObjectType:      types.StringValue(object.objectType),
+				ObjectToken:     types.StringValue(object.token),
+				PermissionToken: types.StringValue(permission.Token),
+				MemberUsername:  types.StringValue(member.MemberUsername),
+				MemberToken:     types.StringValue(member.MemberToken),
+			})
+		}
+	}
+
+	var dataSourcesData struct {
+		Embedded struct {
+			DataSources []struct {
+				DataSourceToken string `json:"token"`
+				Asleep          bool   `json:"asleep"`
+				Queryable       bool   `json:"queryable"`
+				SoftDeleted     bool   `json:"soft_deleted"`
+			} `json:"data_sources"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/data_sources", d.modeHost, d.workspaceId)
+	if err := HttpGetJSON(ctx, d.client, url, &dataSourcesData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list data sources: %s", err))
+		return
+	}
+
+	asleepDataSources := []string{}
+	unqueryableDataSources := []string{}
+	for _, dataSource := range dataSourcesData.Embedded.DataSources {
+		if dataSource.SoftDeleted {
+			continue
+		}
+		if dataSource.Asleep {
+			asleepDataSources = append(asleepDataSources, dataSource.DataSourceToken)
+		}
+		if !dataSource.Queryable {
+			unqueryableDataSources = append(unqueryableDataSources, dataSource.DataSourceToken)
+		}
+	}
+
+	data.EmptyGroups = sortedStringValues(emptyGroups)
+	data.CollectionsWithoutPermissions = sortedStringValues(collectionsWithoutPermissions)
+	data.AsleepDataSources = sortedStringValues(asleepDataSources)
+	data.UnqueryableDataSources = sortedStringValues(unqueryableDataSources)
+	data.UngroupedMembers = sortedStringValues(ungroupedMembers)
+	data.IssueCount = types.Int64Value(int64(len(emptyGroups) + len(collectionsWithoutPermissions) + len(data.DeactivatedMemberPermissions) +
+		len(asleepDataSources) + len(unqueryableDataSources) + len(ungroupedMembers)))
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sortedStringValues sorts values and converts them into framework strings.
+func sortedStringValues(values []string) []types.String {
+	sort.Strings(values)
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
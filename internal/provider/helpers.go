@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // CheckDeletion verifies the deletion of a resource by attempting to read it every 10 seconds for a minute.
@@ -78,6 +86,65 @@ func CheckDeletion(resourceURL string, client *http.Client) error {
 	}
 }
 
+// HttpGetJSON performs a GET request through HttpRetry and decodes the JSON response into target.
+// It returns an error if the status code is anything other than 200.
+func HttpGetJSON(ctx context.Context, client *http.Client, url string, target interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(target); err != nil {
+		return fmt.Errorf("error parsing response: %s", err)
+	}
+	return nil
+}
+
+// LookupDataSourceId returns the ID of the data source with the given token, as expected by
+// endpoints that reference data sources by ID.
+func LookupDataSourceId(ctx context.Context, client *http.Client, modeHost string, workspaceId string, dataSourceToken string) (string, error) {
+	var responseData struct {
+		Id string `json:"id"`
+	}
+	url := fmt.Sprintf("%s/api/%s/data_sources/%s", modeHost, workspaceId, dataSourceToken)
+	if err := HttpGetJSON(ctx, client, url, &responseData); err != nil {
+		return "", err
+	}
+	return responseData.Id, nil
+}
+
+// LookupDataSourceToken returns the token of the data source with the given ID.
+func LookupDataSourceToken(ctx context.Context, client *http.Client, modeHost string, workspaceId string, dataSourceId string) (string, error) {
+	var responseData struct {
+		Embedded struct {
+			DataSources []struct {
+				Id              string `json:"id"`
+				DataSourceToken string `json:"token"`
+			} `json:"data_sources"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/data_sources", modeHost, workspaceId)
+	if err := HttpGetJSON(ctx, client, url, &responseData); err != nil {
+		return "", err
+	}
+	for _, dataSource := range responseData.Embedded.DataSources {
+		if dataSource.Id == dataSourceId {
+			return dataSource.DataSourceToken, nil
+		}
+	}
+	return "", fmt.Errorf("data source %s not found", dataSourceId)
+}
+
 func HttpRetry(client *http.Client, httpReq *http.Request) (*http.Response, error) {
 	sleep := 10 * time.Second
 	attempts := 9
@@ -94,3 +161,398 @@ func HttpRetry(client *http.Client, httpReq *http.Request) (*http.Response, erro
 	}
 	return httpResp, err
 }
+
+// PollWithBackoff calls check until it reports completion, returns an error or the timeout elapses.
+// The wait between attempts starts at minInterval and doubles after every attempt up to maxInterval.
+func PollWithBackoff(ctx context.Context, timeout time.Duration, minInterval time.Duration, maxInterval time.Duration, check func() (bool, error)) error {
+	deadline := time.After(timeout)
+	interval := minInterval
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("polling timed out after %s", timeout)
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// WorkspaceMember is a member of the workspace as returned by the memberships endpoint.
+type WorkspaceMember struct {
+	Admin          bool   `json:"admin"`
+	State          string `json:"state"`
+	MemberUsername string `json:"member_username"`
+	MemberToken    string `json:"member_token"`
+	ActivatedAt    string `json:"activated_at"`
+}
+
+// ListWorkspaceMembers returns every member of the workspace.
+func ListWorkspaceMembers(ctx context.Context, client *http.Client, modeHost string, workspaceId string) ([]WorkspaceMember, error) {
+	var responseData struct {
+		Embedded struct {
+			Memberships []WorkspaceMember `json:"memberships"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/memberships", modeHost, workspaceId)
+	if err := HttpGetJSON(ctx, client, url, &responseData); err != nil {
+		return nil, err
+	}
+	return responseData.Embedded.Memberships, nil
+}
+
+// LookupAccountEmail returns the email address of the account with the given username.
+func LookupAccountEmail(ctx context.Context, client *http.Client, modeHost string, username string) (string, error) {
+	var responseData struct {
+		Email string `json:"email"`
+	}
+	url := fmt.Sprintf("%s/api/%s", modeHost, username)
+	if err := HttpGetJSON(ctx, client, url, &responseData); err != nil {
+		return "", err
+	}
+	return responseData.Email, nil
+}
+
+// MatchMemberEmails returns the members whose account email is one of emails, keyed by the lowercased email.
+// Memberships do not include email addresses, so accounts are read one by one through cache until every
+// email is matched. Deactivated members are skipped when activeOnly is set.
+func MatchMemberEmails(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, members []WorkspaceMember, emails []string, activeOnly bool) (map[string]WorkspaceMember, error) {
+	wanted := map[string]bool{}
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	matched := map[string]WorkspaceMember{}
+	for _, member := range members {
+		if len(matched) == len(wanted) {
+			break
+		}
+		if activeOnly && member.State == "deactivated" {
+			continue
+		}
+
+		email, err := cache.AccountEmail(ctx, client, modeHost, member.MemberUsername)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read account %s: %s", member.MemberUsername, err)
+		}
+		email = strings.ToLower(email)
+		if _, ok := matched[email]; !ok && wanted[email] {
+			matched[email] = member
+		}
+	}
+	return matched, nil
+}
+
+// ResolveMemberToken returns the member token of an active workspace member by username.
+// It returns an error if the username is unknown or the member is deactivated. The memberships are read through cache.
+func ResolveMemberToken(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, workspaceId string, username string) (string, error) {
+	members, err := cache.Members(ctx, client, modeHost, workspaceId)
+	if err != nil {
+		return "", fmt.Errorf("unable to list memberships: %s", err)
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.MemberUsername, username) {
+			if member.State == "deactivated" {
+				return "", fmt.Errorf("member %q is deactivated", username)
+			}
+			return member.MemberToken, nil
+		}
+	}
+	return "", fmt.Errorf("no member with username %q in workspace", username)
+}
+
+// ResolveMemberUsernamePlan sets the planned value of tokenPath to the member token of the username
+// planned at usernamePath. It is meant to be called from ModifyPlan of resources that accept a
+// member_username instead of a raw token, and requires replacement when the resolved token changes.
+func ResolveMemberUsernamePlan(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, workspaceId string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, usernamePath path.Path, tokenPath path.Path) {
+	// The provider is not configured yet during validation of unknown provider configuration
+	if client == nil {
+		ResolveUsernamePlan(ctx, req, resp, usernamePath, tokenPath, nil)
+		return
+	}
+
+	ResolveUsernamePlan(ctx, req, resp, usernamePath, tokenPath, func(username string) (string, error) {
+		return ResolveMemberToken(ctx, cache, client, modeHost, workspaceId, username)
+	})
+}
+
+// ResolveUsernamePlan is ResolveMemberUsernamePlan with the lookup of the token left to resolve.
+// A nil resolve only plans the token as unknown when the username is.
+func ResolveUsernamePlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, usernamePath path.Path, tokenPath path.Path, resolve func(username string) (string, error)) {
+	// Nothing to resolve on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var username types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, usernamePath, &username)...)
+	if resp.Diagnostics.HasError() || username.IsNull() {
+		return
+	}
+
+	if username.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tokenPath, types.StringUnknown())...)
+		return
+	}
+
+	if resolve == nil {
+		return
+	}
+
+	token, err := resolve(username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(usernamePath, "Unable to Resolve Member", fmt.Sprintf("Unable to resolve member_username to a member token: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tokenPath, types.StringValue(token))...)
+
+	if !req.State.Raw.IsNull() {
+		var stateToken types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tokenPath, &stateToken)...)
+		if stateToken.ValueString() != token {
+			resp.RequiresReplace = append(resp.RequiresReplace, tokenPath)
+		}
+	}
+}
+
+// RestoreSoftDeleted un-deletes the soft-deleted object at url. The object keeps its token.
+func RestoreSoftDeleted(ctx context.Context, client *http.Client, url string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/restore", nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// PlanSoftDeletedRestore plans an update of a soft-deleted object when restore_soft_deleted is set,
+// so that the restore happens during apply. Read keeps such objects in the state instead of removing them.
+func PlanSoftDeletedRestore(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to restore on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var restore types.Bool
+	var state types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("restore_soft_deleted"), &restore)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("state"), &state)...)
+	if resp.Diagnostics.HasError() || !restore.ValueBool() || state.ValueString() != "soft_deleted" {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("state"), types.StringUnknown())...)
+	resp.Diagnostics.AddWarning(
+		"Soft-Deleted Object Will Be Restored",
+		"The object was deleted outside of Terraform and will be restored with its existing token during apply.",
+	)
+}
+
+// CheckReadOnly adds an error to diags when the provider is configured with read_only.
+// It returns true if the calling Create, Update or Delete must stop.
+func CheckReadOnly(readOnly bool, diags *diag.Diagnostics) bool {
+	if !readOnly {
+		return false
+	}
+	diags.AddError(
+		"Provider Is Read-Only",
+		"The provider is configured with read_only = true, so no changes can be applied. Refresh and data sources are still available.",
+	)
+	return true
+}
+
+// CheckWritePlan runs the checks of CheckReadOnly and CheckDeletionProtection while planning, so that a plan
+// the provider would refuse to apply fails before anything is changed. It is deferred at the top of ModifyPlan
+// to see the replacements planned by the resource itself. Resources without deletion_protection only get the read_only check.
+func CheckWritePlan(ctx context.Context, readOnly bool, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !resp.Plan.Raw.Equal(req.State.Raw) {
+		CheckReadOnly(readOnly, &resp.Diagnostics)
+	}
+
+	if req.State.Raw.IsNull() || (!resp.Plan.Raw.IsNull() && len(resp.RequiresReplace) == 0) {
+		return
+	}
+	if _, ok := req.State.Schema.GetAttributes()["deletion_protection"]; !ok {
+		return
+	}
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	CheckDeletionProtection(deletionProtection, &resp.Diagnostics)
+}
+
+// CheckScimGroups adds an error to diags when groups are managed through SCIM. Group tokens are then SCIM ids,
+// which the REST API used by the calling resource does not accept. It returns true if the plan must stop.
+func CheckScimGroups(scim *ScimClient, attribute path.Path, diags *diag.Diagnostics) bool {
+	if scim == nil {
+		return false
+	}
+	diags.AddAttributeError(
+		attribute,
+		"Unsupported User Management Backend",
+		"Groups are managed through SCIM (user_management_backend = scim), so their tokens are SCIM ids that this resource cannot reference. Use the rest backend to grant permissions to groups.",
+	)
+	return true
+}
+
+// CheckDeletionProtection adds an error to diags when deletion_protection is enabled in the state.
+// It returns true if the calling Delete must stop.
+func CheckDeletionProtection(deletionProtection types.Bool, diags *diag.Diagnostics) bool {
+	if !deletionProtection.ValueBool() {
+		return false
+	}
+	diags.AddError(
+		"Deletion Protection Enabled",
+		"The resource has deletion_protection = true. Set it to false and apply before destroying or replacing the resource.",
+	)
+	return true
+}
+
+// CheckReferencedObject reads the object at url and returns an error if it does not exist or is soft-deleted.
+func CheckReferencedObject(ctx context.Context, client *http.Client, url string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	switch httpResp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		// Deleted collections can answer with 403 instead of 404
+		return fmt.Errorf("does not exist or is not accessible")
+	default:
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	var responseData struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&responseData); err != nil {
+		return fmt.Errorf("error parsing response: %s", err)
+	}
+	if responseData.State == "soft_deleted" {
+		return fmt.Errorf("is soft-deleted")
+	}
+	return nil
+}
+
+// CheckReferencedMember returns an error if memberToken is not a member of the workspace.
+// The returned bool reports whether the member is deactivated. The memberships are read through cache.
+func CheckReferencedMember(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, workspaceId string, memberToken string) (bool, error) {
+	members, err := cache.Members(ctx, client, modeHost, workspaceId)
+	if err != nil {
+		return false, fmt.Errorf("unable to list memberships: %s", err)
+	}
+	for _, member := range members {
+		if member.MemberToken == memberToken {
+			return member.State == "deactivated", nil
+		}
+	}
+	return false, fmt.Errorf("is not a member of the workspace")
+}
+
+// PlannedReference returns the planned value at p and whether it should be validated, which is
+// the case when it is known and either the resource is being created or the value changes.
+func PlannedReference(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path) (string, bool) {
+	if resp.Plan.Raw.IsNull() {
+		return "", false
+	}
+
+	var planned types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &planned)...)
+	if planned.IsNull() || planned.IsUnknown() {
+		return "", false
+	}
+
+	if !req.State.Raw.IsNull() {
+		var current types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &current)...)
+		if current.ValueString() == planned.ValueString() {
+			return "", false
+		}
+	}
+	return planned.ValueString(), true
+}
+
+// ValidateReferencePlan raises a plan-time error when the object at url, referenced by the planned value at p, is unusable.
+func ValidateReferencePlan(ctx context.Context, client *http.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path, kind string, urlFormat string) {
+	token, ok := PlannedReference(ctx, req, resp, p)
+	if !ok || client == nil {
+		return
+	}
+
+	if err := CheckReferencedObject(ctx, client, fmt.Sprintf(urlFormat, token)); err != nil {
+		resp.Diagnostics.AddAttributeError(p, "Invalid Reference", fmt.Sprintf("The %s %q %s", kind, token, err))
+	}
+}
+
+// ValidateMemberPlan raises a plan-time error when the member referenced by the planned value at p does not exist,
+// and a warning when it is deactivated.
+func ValidateMemberPlan(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, workspaceId string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, p path.Path) {
+	token, ok := PlannedReference(ctx, req, resp, p)
+	if !ok || client == nil {
+		return
+	}
+
+	deactivated, err := CheckReferencedMember(ctx, cache, client, modeHost, workspaceId, token)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(p, "Invalid Reference", fmt.Sprintf("The member %q %s", token, err))
+		return
+	}
+	if deactivated {
+		resp.Diagnostics.AddAttributeWarning(p, "Deactivated Member", fmt.Sprintf("The member %q is deactivated. The change will apply but has no effect until the member is reactivated.", token))
+	}
+}
+
+// ValidateAccessorPlan validates the planned accessor_token of a permission against its accessor_type.
+func ValidateAccessorPlan(ctx context.Context, cache *ReadCache, client *http.Client, modeHost string, workspaceId string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Plan.Raw.IsNull() {
+		return
+	}
+
+	var accessorType types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("accessor_type"), &accessorType)...)
+
+	switch accessorType.ValueString() {
+	case "Account":
+		ValidateMemberPlan(ctx, cache, client, modeHost, workspaceId, req, resp, path.Root("accessor_token"))
+	case "UserGroup":
+		ValidateReferencePlan(ctx, client, req, resp, path.Root("accessor_token"), "group", modeHost+"/api/"+workspaceId+"/groups/%s")
+	}
+}
+
+// durationPattern matches the duration strings accepted by time.ParseDuration.
+var durationPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
+// DurationValidator validates that a string attribute is a Go duration such as 30m.
+func DurationValidator() validator.String {
+	return stringvalidator.RegexMatches(durationPattern, "must be a duration such as 90s, 30m or 1h")
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	cases := map[string]bool{
+		"30m":    true,
+		"90s":    true,
+		"1h30m":  true,
+		"1.5h":   true,
+		"250ms":  true,
+		"":       false,
+		"30":     false,
+		"1d":     false,
+		"-5m":    false,
+		"30 m":   false,
+		"thirty": false,
+	}
+
+	for value, valid := range cases {
+		req := validator.StringRequest{Path: path.Root("timeout"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		DurationValidator().ValidateString(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("DurationValidator(%q) error = %v, want valid = %v", value, resp.Diagnostics.HasError(), valid)
+		}
+	}
+
+	// Unknown and null values are left to Terraform
+	for _, value := range []types.String{types.StringUnknown(), types.StringNull()} {
+		resp := &validator.StringResponse{}
+		DurationValidator().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("timeout"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("DurationValidator(%s) reported %v", value, resp.Diagnostics)
+		}
+	}
+}
+
+// modeStandIn serves canned JSON responses of the Mode API by request URI and counts the requests
+// per method and URI. Unknown URIs answer with 404.
+type modeStandIn struct {
+	URL       string
+	mu        sync.Mutex
+	responses map[string]interface{}
+	requests  map[string]int
+	bodies    map[string][]string
+}
+
+func newModeStandIn(t *testing.T, responses map[string]interface{}) *modeStandIn {
+	standIn := &modeStandIn{responses: responses, requests: map[string]int{}, bodies: map[string][]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		standIn.mu.Lock()
+		defer standIn.mu.Unlock()
+
+		key := req.Method + " " + req.URL.RequestURI()
+		standIn.requests[key]++
+		var body json.RawMessage
+		if json.NewDecoder(req.Body).Decode(&body) == nil {
+			standIn.bodies[key] = append(standIn.bodies[key], string(body))
+		}
+
+		response, ok := standIn.responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	standIn.URL = server.URL
+	return standIn
+}
+
+// count returns the number of requests received for method and uri.
+func (s *modeStandIn) count(method string, uri string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+uri]
+}
+
+func testMemberships() map[string]interface{} {
+	return map[string]interface{}{
+		"_embedded": map[string]interface{}{
+			"memberships": []WorkspaceMember{
+				{MemberUsername: "ada", MemberToken: "m-ada", State: "active"},
+				{MemberUsername: "bob", MemberToken: "m-bob", State: "deactivated"},
+				{MemberUsername: "cyd", MemberToken: "m-cyd", State: "active"},
+			},
+		},
+	}
+}
+
+func TestResolveMemberTokenReadsMembershipsOnce(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"GET /api/ws/memberships": testMemberships(),
+	})
+	cache := NewReadCache()
+
+	token, err := ResolveMemberToken(ctx, cache, http.DefaultClient, standIn.URL, "ws", "ADA")
+	if err != nil || token != "m-ada" {
+		t.Fatalf("ResolveMemberToken(ADA) = %q, %v, want m-ada", token, err)
+	}
+	if _, err := ResolveMemberToken(ctx, cache, http.DefaultClient, standIn.URL, "ws", "bob"); err == nil {
+		t.Errorf("ResolveMemberToken of a deactivated member succeeded")
+	}
+	if _, err := ResolveMemberToken(ctx, cache, http.DefaultClient, standIn.URL, "ws", "eve"); err == nil {
+		t.Errorf("ResolveMemberToken of an unknown member succeeded")
+	}
+	if got := standIn.count("GET", "/api/ws/memberships"); got != 1 {
+		t.Errorf("memberships were listed %d times, want 1", got)
+	}
+
+	// Without a cache every call lists the memberships
+	for i := 0; i < 2; i++ {
+		if _, err := ResolveMemberToken(ctx, nil, http.DefaultClient, standIn.URL, "ws", "cyd"); err != nil {
+			t.Fatalf("ResolveMemberToken without cache: %s", err)
+		}
+	}
+	if got := standIn.count("GET", "/api/ws/memberships"); got != 3 {
+		t.Errorf("memberships were listed %d times, want 3", got)
+	}
+}
+
+func TestMatchMemberEmails(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"GET /api/ada": map[string]string{"email": "Ada@example.com"},
+		"GET /api/bob": map[string]string{"email": "bob@example.com"},
+		"GET /api/cyd": map[string]string{"email": "cyd@example.com"},
+	})
+	members := testMemberships()["_embedded"].(map[string]interface{})["memberships"].([]WorkspaceMember)
+	cache := NewReadCache()
+
+	matched, err := MatchMemberEmails(ctx, cache, http.DefaultClient, standIn.URL, members, []string{"ada@EXAMPLE.com", "bob@example.com", "eve@example.com"}, true)
+	if err != nil {
+		t.Fatalf("MatchMemberEmails: %s", err)
+	}
+	if len(matched) != 1 || matched["ada@example.com"].MemberToken != "m-ada" {
+		t.Errorf("MatchMemberEmails = %v, want only ada, as bob is deactivated and eve unknown", matched)
+	}
+
+	// Accounts read before come from the cache, and reading stops once every email is matched
+	matched, err = MatchMemberEmails(ctx, cache, http.DefaultClient, standIn.URL, members, []string{"ada@example.com"}, false)
+	if err != nil || len(matched) != 1 {
+		t.Fatalf("MatchMemberEmails = %v, %v", matched, err)
+	}
+	for _, username := range []string{"ada", "cyd"} {
+		if got := standIn.count("GET", "/api/"+username); got != 1 {
+			t.Errorf("account %s was read %d times, want 1", username, got)
+		}
+	}
+	if got := standIn.count("GET", "/api/bob"); got != 0 {
+		t.Errorf("deactivated account bob was read %d times, want 0", got)
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GovernancePolicy holds the rules of the provider policy block. Resources evaluate them while
+// planning, so that violations are reported before anything is applied. A nil policy allows everything.
+type GovernancePolicy struct {
+	CollectionAccessorTypes      []string
+	DataSourceAccessorTypes      []string
+	CollectionActions            []string
+	DataSourceActions            []string
+	DataSourceManageGroupTokens  []string
+	RequireRestrictedCollections bool
+	CollectionNamePattern        *regexp.Regexp
+}
+
+// CollectionPermissionViolations returns the rules broken by a collection permission.
+func (p *GovernancePolicy) CollectionPermissionViolations(accessorType string, action string) []string {
+	if p == nil {
+		return nil
+	}
+
+	violations := []string{}
+	if !allowedBy(p.CollectionAccessorTypes, accessorType) {
+		violations = append(violations, fmt.Sprintf("collections can only be granted to %s, not %s", strings.Join(p.CollectionAccessorTypes, ", "), accessorType))
+	}
+	if !allowedBy(p.CollectionActions, action) {
+		violations = append(violations, fmt.Sprintf("the action on collections must be one of %s, not %s", strings.Join(p.CollectionActions, ", "), action))
+	}
+	return violations
+}
+
+// DataSourcePermissionViolations returns the rules broken by a data source permission, other than those
+// about its accessor token, which DataSourceManageViolations checks.
+func (p *GovernancePolicy) DataSourcePermissionViolations(accessorType string, action string) []string {
+	if p == nil {
+		return nil
+	}
+
+	violations := []string{}
+	if !allowedBy(p.DataSourceAccessorTypes, accessorType) {
+		violations = append(violations, fmt.Sprintf("data sources can only be granted to %s, not %s", strings.Join(p.DataSourceAccessorTypes, ", "), accessorType))
+	}
+	if !allowedBy(p.DataSourceActions, action) {
+		violations = append(violations, fmt.Sprintf("the action on data sources must be one of %s, not %s", strings.Join(p.DataSourceActions, ", "), action))
+	}
+	return violations
+}
+
+// DataSourceManageViolations returns the rules broken by granting action on a data source to accessorToken.
+// They are kept apart from DataSourcePermissionViolations as the token may only be known during the apply.
+func (p *GovernancePolicy) DataSourceManageViolations(accessorType string, accessorToken string, action string) []string {
+	if p == nil {
+		return nil
+	}
+
+	violations := []string{}
+	if action == "manage" && len(p.DataSourceManageGroupTokens) > 0 && (accessorType != "UserGroup" || !allowedBy(p.DataSourceManageGroupTokens, accessorToken)) {
+		violations = append(violations, fmt.Sprintf("manage on data sources is limited to the groups %s", strings.Join(p.DataSourceManageGroupTokens, ", ")))
+	}
+	return violations
+}
+
+// CollectionViolations returns the rules broken by a collection.
+func (p *GovernancePolicy) CollectionViolations(name string, restricted bool) []string {
+	if p == nil {
+		return nil
+	}
+
+	violations := []string{}
+	if p.RequireRestrictedCollections && !restricted {
+		violations = append(violations, "collections must be restricted")
+	}
+	if p.CollectionNamePattern != nil && !p.CollectionNamePattern.MatchString(name) {
+		violations = append(violations, fmt.Sprintf("the collection name %q does not match %s", name, p.CollectionNamePattern.String()))
+	}
+	return violations
+}
+
+// allowedBy reports whether value is in allowed. An empty list allows every value.
+func allowedBy(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, candidate := range allowed {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
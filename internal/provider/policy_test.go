@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGovernancePolicyNilAllowsEverything(t *testing.T) {
+	var policy *GovernancePolicy
+	if v := policy.CollectionPermissionViolations("Account", "edit"); len(v) != 0 {
+		t.Errorf("CollectionPermissionViolations = %v", v)
+	}
+	if v := policy.DataSourcePermissionViolations("Account", "manage"); len(v) != 0 {
+		t.Errorf("DataSourcePermissionViolations = %v", v)
+	}
+	if v := policy.DataSourceManageViolations("Account", "m-ada", "manage"); len(v) != 0 {
+		t.Errorf("DataSourceManageViolations = %v", v)
+	}
+	if v := policy.CollectionViolations("anything", false); len(v) != 0 {
+		t.Errorf("CollectionViolations = %v", v)
+	}
+}
+
+func TestGovernancePolicyViolations(t *testing.T) {
+	policy := &GovernancePolicy{
+		CollectionAccessorTypes:      []string{"UserGroup"},
+		DataSourceAccessorTypes:      []string{"UserGroup"},
+		CollectionActions:            []string{"view"},
+		DataSourceActions:            []string{"view", "query", "manage"},
+		DataSourceManageGroupTokens:  []string{"g-admins"},
+		RequireRestrictedCollections: true,
+		CollectionNamePattern:        regexp.MustCompile(`^team-`),
+	}
+
+	cases := []struct {
+		name       string
+		violations []string
+		want       int
+	}{
+		{"allowed collection permission", policy.CollectionPermissionViolations("UserGroup", "view"), 0},
+		{"collection permission to an account", policy.CollectionPermissionViolations("Account", "view"), 1},
+		{"collection permission with a forbidden action", policy.CollectionPermissionViolations("Account", "edit"), 2},
+		{"allowed data source permission", policy.DataSourcePermissionViolations("UserGroup", "query"), 0},
+		{"data source permission to an account", policy.DataSourcePermissionViolations("Account", "view"), 1},
+		{"manage by an allowed group", policy.DataSourceManageViolations("UserGroup", "g-admins", "manage"), 0},
+		{"manage by another group", policy.DataSourceManageViolations("UserGroup", "g-analysts", "manage"), 1},
+		{"manage by an account", policy.DataSourceManageViolations("Account", "g-admins", "manage"), 1},
+		{"query by another group", policy.DataSourceManageViolations("UserGroup", "g-analysts", "query"), 0},
+		{"allowed collection", policy.CollectionViolations("team-data", true), 0},
+		{"unrestricted collection", policy.CollectionViolations("team-data", false), 1},
+		{"misnamed unrestricted collection", policy.CollectionViolations("scratch", false), 2},
+	}
+	for _, c := range cases {
+		if len(c.violations) != c.want {
+			t.Errorf("%s: violations = %v, want %d", c.name, c.violations, c.want)
+		}
+	}
+}
+
+func TestBuildGovernancePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	policy, err := buildGovernancePolicy(ctx, nil)
+	if err != nil || policy != nil {
+		t.Errorf("buildGovernancePolicy(nil) = %v, %v, want nil, nil", policy, err)
+	}
+
+	actions, _ := types.ListValueFrom(ctx, types.StringType, []string{"view"})
+	policy, err = buildGovernancePolicy(ctx, &ProviderPolicyModel{
+		CollectionAccessorTypes:     types.ListNull(types.StringType),
+		DataSourceAccessorTypes:     types.ListUnknown(types.StringType),
+		CollectionActions:           actions,
+		DataSourceActions:           types.ListNull(types.StringType),
+		DataSourceManageGroupTokens: types.ListNull(types.StringType),
+		CollectionNamePattern:       types.StringValue(`^team-`),
+	})
+	if err != nil {
+		t.Fatalf("buildGovernancePolicy: %s", err)
+	}
+	if len(policy.CollectionActions) != 1 || policy.CollectionAccessorTypes != nil || !policy.CollectionNamePattern.MatchString("team-a") {
+		t.Errorf("buildGovernancePolicy = %+v", policy)
+	}
+
+	_, err = buildGovernancePolicy(ctx, &ProviderPolicyModel{
+		CollectionAccessorTypes:     types.ListNull(types.StringType),
+		DataSourceAccessorTypes:     types.ListNull(types.StringType),
+		CollectionActions:           types.ListNull(types.StringType),
+		DataSourceActions:           types.ListNull(types.StringType),
+		DataSourceManageGroupTokens: types.ListNull(types.StringType),
+		CollectionNamePattern:       types.StringValue(`(`),
+	})
+	if err == nil {
+		t.Errorf("buildGovernancePolicy accepted an invalid collection_name_pattern")
+	}
+}
+
+var (
+	testRoleType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"collection_action":  types.StringType,
+		"data_source_action": types.StringType,
+	}}
+	testBindingType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"role":               types.StringType,
+		"group_token":        types.StringType,
+		"collection_tokens":  types.SetType{ElemType: types.StringType},
+		"data_source_tokens": types.SetType{ElemType: types.StringType},
+	}}
+)
+
+func testBinding(role string, groupToken types.String, collectionTokens types.Set, dataSourceTokens types.Set) attr.Value {
+	return types.ObjectValueMust(testBindingType.AttrTypes, map[string]attr.Value{
+		"role":               types.StringValue(role),
+		"group_token":        groupToken,
+		"collection_tokens":  collectionTokens,
+		"data_source_tokens": dataSourceTokens,
+	})
+}
+
+func TestAccessPolicyViolationsWithUnknownBindings(t *testing.T) {
+	ctx := context.Background()
+	governance := &GovernancePolicy{
+		CollectionActions:           []string{"view"},
+		DataSourceManageGroupTokens: []string{"g-admins"},
+	}
+	roles := types.MapValueMust(testRoleType, map[string]attr.Value{
+		"editor": types.ObjectValueMust(testRoleType.AttrTypes, map[string]attr.Value{
+			"collection_action":  types.StringValue("edit"),
+			"data_source_action": types.StringNull(),
+		}),
+		"owner": types.ObjectValueMust(testRoleType.AttrTypes, map[string]attr.Value{
+			"collection_action":  types.StringNull(),
+			"data_source_action": types.StringValue("manage"),
+		}),
+	})
+	tokens := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("c1")})
+	noTokens := types.SetNull(types.StringType)
+
+	bindings := types.ListValueMust(testBindingType, []attr.Value{
+		// Unknown bindings are skipped
+		types.ObjectUnknown(testBindingType.AttrTypes),
+		// The collection action is known even though the group is created in the same apply
+		testBinding("editor", types.StringUnknown(), types.SetUnknown(types.StringType), noTokens),
+		// The group of manage grants is only checked once it is known
+		testBinding("owner", types.StringUnknown(), noTokens, tokens),
+		testBinding("owner", types.StringValue("g-analysts"), noTokens, tokens),
+		testBinding("owner", types.StringValue("g-admins"), noTokens, tokens),
+	})
+
+	diags := accessPolicyViolations(ctx, AccessPolicyResourceModel{Roles: roles, Bindings: bindings}, governance)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("accessPolicyViolations reported %d errors, want 2: %v", diags.ErrorsCount(), diags)
+	}
+
+	// Nothing can be checked while the bindings are unknown as a whole
+	diags = accessPolicyViolations(ctx, AccessPolicyResourceModel{Roles: roles, Bindings: types.ListUnknown(testBindingType)}, governance)
+	if diags.HasError() {
+		t.Errorf("accessPolicyViolations with unknown bindings = %v", diags)
+	}
+}
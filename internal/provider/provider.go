@@ -2,13 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,10 +33,27 @@ type ScaffoldingProvider struct {
 
 // ScaffoldingProviderModel describes the provider data model.
 type ScaffoldingProviderModel struct {
-	ModeHost    types.String `tfsdk:"mode_host"`
-	ApiToken    types.String `tfsdk:"api_token"`
-	ApiSecret   types.String `tfsdk:"api_secret"`
-	WorkspaceId types.String `tfsdk:"workspace_id"`
+	ModeHost    types.String         `tfsdk:"mode_host"`
+	ApiToken    types.String         `tfsdk:"api_token"`
+	ApiSecret   types.String         `tfsdk:"api_secret"`
+	WorkspaceId types.String         `tfsdk:"workspace_id"`
+	ReadOnly    types.Bool           `tfsdk:"read_only"`
+	Policy      *ProviderPolicyModel `tfsdk:"policy"`
+
+	UserManagementBackend types.String `tfsdk:"user_management_backend"`
+	ScimToken             types.String `tfsdk:"scim_token"`
+	ScimBaseURL           types.String `tfsdk:"scim_base_url"`
+}
+
+// ProviderPolicyModel describes the policy block of the provider.
+type ProviderPolicyModel struct {
+	CollectionAccessorTypes      types.List   `tfsdk:"allowed_collection_accessor_types"`
+	DataSourceAccessorTypes      types.List   `tfsdk:"allowed_data_source_accessor_types"`
+	CollectionActions            types.List   `tfsdk:"allowed_collection_actions"`
+	DataSourceActions            types.List   `tfsdk:"allowed_data_source_actions"`
+	DataSourceManageGroupTokens  types.List   `tfsdk:"data_source_manage_group_tokens"`
+	RequireRestrictedCollections types.Bool   `tfsdk:"require_restricted_collections"`
+	CollectionNamePattern        types.String `tfsdk:"collection_name_pattern"`
 }
 
 func (p *ScaffoldingProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -57,6 +82,69 @@ func (p *ScaffoldingProvider) Schema(ctx context.Context, req provider.SchemaReq
 				MarkdownDescription: "Workspace ID for Mode Analytics",
 				Optional:            true,
 			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Fail every plan that would create, update or delete a resource while still allowing refresh and data sources",
+				Optional:            true,
+			},
+			"user_management_backend": schema.StringAttribute{
+				MarkdownDescription: "API used by the group and group membership resources: `rest` (default) or `scim`. " +
+					"Under `scim`, group and member tokens are SCIM ids, so groups cannot be granted permissions or used by " +
+					"`modeanalytics_access_policy` and `modeanalytics_group_sync`, and the data sources keep listing REST tokens. " +
+					"Existing groups and memberships must be re-imported with their SCIM ids after switching",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("rest", "scim"),
+				},
+			},
+			"scim_token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token for the SCIM API, required when `user_management_backend` is `scim`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"scim_base_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the SCIM API. Defaults to `<mode_host>/api/<workspace_id>/scim/v2`; point it at a local SCIM stand-in for testing",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"policy": schema.SingleNestedBlock{
+				MarkdownDescription: "Governance rules evaluated while planning collections and permissions. Unset rules allow everything",
+				Attributes: map[string]schema.Attribute{
+					"allowed_collection_accessor_types": schema.ListAttribute{
+						MarkdownDescription: "Accessor types collections can be granted to, such as `UserGroup`",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"allowed_data_source_accessor_types": schema.ListAttribute{
+						MarkdownDescription: "Accessor types data sources can be granted to, such as `UserGroup`",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"allowed_collection_actions": schema.ListAttribute{
+						MarkdownDescription: "Actions that can be granted on collections",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"allowed_data_source_actions": schema.ListAttribute{
+						MarkdownDescription: "Actions that can be granted on data sources",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"data_source_manage_group_tokens": schema.ListAttribute{
+						MarkdownDescription: "Tokens of the only groups that can be granted `manage` on data sources",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"require_restricted_collections": schema.BoolAttribute{
+						MarkdownDescription: "Disallow collections with `restricted = false`",
+						Optional:            true,
+					},
+					"collection_name_pattern": schema.StringAttribute{
+						MarkdownDescription: "Regular expression collection names must match",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -75,6 +163,10 @@ func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.Config
 	apiToken := os.Getenv("MODE_ANALYTICS_API_TOKEN")
 	apiSecret := os.Getenv("MODE_ANALYTICS_API_SECRET")
 	workspaceId := os.Getenv("MODE_ANALYTICS_WORKSPACE_ID")
+	readOnly, _ := strconv.ParseBool(os.Getenv("MODE_ANALYTICS_READ_ONLY"))
+	userManagementBackend := os.Getenv("MODE_ANALYTICS_USER_MANAGEMENT_BACKEND")
+	scimToken := os.Getenv("MODE_ANALYTICS_SCIM_TOKEN")
+	scimBaseURL := os.Getenv("MODE_ANALYTICS_SCIM_BASE_URL")
 
 	if !data.ModeHost.IsNull() {
 		modeHost = data.ModeHost.ValueString()
@@ -92,6 +184,28 @@ func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.Config
 		workspaceId = data.WorkspaceId.ValueString()
 	}
 
+	if !data.ReadOnly.IsNull() {
+		readOnly = data.ReadOnly.ValueBool()
+	}
+
+	if !data.UserManagementBackend.IsNull() {
+		userManagementBackend = data.UserManagementBackend.ValueString()
+	}
+
+	if !data.ScimToken.IsNull() {
+		scimToken = data.ScimToken.ValueString()
+	}
+
+	if !data.ScimBaseURL.IsNull() {
+		scimBaseURL = data.ScimBaseURL.ValueString()
+	}
+
+	policy, err := buildGovernancePolicy(ctx, data.Policy)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("policy"), "Invalid Policy", err.Error())
+		return
+	}
+
 	// Ensure all required configurations are set
 	if modeHost == "" || apiToken == "" || apiSecret == "" || workspaceId == "" {
 		resp.Diagnostics.AddError(
@@ -101,6 +215,25 @@ func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	var scim *ScimClient
+	switch userManagementBackend {
+	case "", "rest":
+	case "scim":
+		if scimToken == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("scim_token"), "Missing Configuration",
+				"scim_token must be set either as environment variable or in the provider configuration block when user_management_backend is scim.")
+			return
+		}
+		if scimBaseURL == "" {
+			scimBaseURL = fmt.Sprintf("%s/api/%s/scim/v2", modeHost, workspaceId)
+		}
+		scim = NewScimClient(strings.TrimSuffix(scimBaseURL, "/"), scimToken)
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("user_management_backend"), "Invalid Configuration",
+			fmt.Sprintf("user_management_backend must be rest or scim, got: %s", userManagementBackend))
+		return
+	}
+
 	// Example client configuration for data sources and resources
 	client := &http.Client{
 		Transport: &customTransport{
@@ -122,20 +255,75 @@ func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.Config
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	}{
 		Client:      client,
 		ModeHost:    modeHost,
 		WorkspaceId: workspaceId,
+		ReadOnly:    readOnly,
+		Cache:       NewReadCache(),
+		Policy:      policy,
+		Scim:        scim,
+	}
+}
+
+// buildGovernancePolicy converts the policy block into a GovernancePolicy. A missing block yields a nil policy.
+func buildGovernancePolicy(ctx context.Context, data *ProviderPolicyModel) (*GovernancePolicy, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	policy := &GovernancePolicy{
+		RequireRestrictedCollections: data.RequireRestrictedCollections.ValueBool(),
+	}
+	lists := map[*[]string]types.List{
+		&policy.CollectionAccessorTypes:     data.CollectionAccessorTypes,
+		&policy.DataSourceAccessorTypes:     data.DataSourceAccessorTypes,
+		&policy.CollectionActions:           data.CollectionActions,
+		&policy.DataSourceActions:           data.DataSourceActions,
+		&policy.DataSourceManageGroupTokens: data.DataSourceManageGroupTokens,
+	}
+	for target, list := range lists {
+		if list.IsNull() || list.IsUnknown() {
+			continue
+		}
+		if diags := list.ElementsAs(ctx, target, false); diags.HasError() {
+			return nil, fmt.Errorf("unable to read policy list: %v", diags)
+		}
+	}
+
+	if data.CollectionNamePattern.ValueString() != "" {
+		pattern, err := regexp.Compile(data.CollectionNamePattern.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("collection_name_pattern is not a valid regular expression: %s", err)
+		}
+		policy.CollectionNamePattern = pattern
 	}
+	return policy, nil
 }
 
 func (p *ScaffoldingProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewGroupResource,
 		NewGroupMembershipResource,
+		NewGroupSyncResource,
 		NewDataSourcePermissionResource,
 		NewCollectionResource,
 		NewCollectionPermissionResource,
+		NewReportRunResource,
+		NewDefinitionResource,
+		NewDatasetResource,
+		NewThemeResource,
+		NewReportThemeResource,
+		NewEmbedKeyResource,
+		NewApiTokenResource,
+		NewAccessPolicyResource,
+		NewReportBundleResource,
+		NewReportCloneResource,
+		NewReportSharingResource,
 	}
 }
 
@@ -149,6 +337,14 @@ func (p *ScaffoldingProvider) DataSources(ctx context.Context) []func() datasour
 		NewDataSourcesDataSource,
 		NewCollectionDataSource,
 		NewCollectionsDataSource,
+		NewQueryRunResultsDataSource,
+		NewReportRunsDataSource,
+		NewDatasetDataSource,
+		NewMemberDataSource,
+		NewCurrentAccountDataSource,
+		NewEffectiveAccessDataSource,
+		NewWorkspaceHygieneDataSource,
+		NewSharedReportsDataSource,
 	}
 }
 
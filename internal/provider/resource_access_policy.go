@@ -0,0 +1,710 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccessPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &AccessPolicyResource{}
+
+// NewAccessPolicyResource returns a new instance of AccessPolicyResource.
+func NewAccessPolicyResource() resource.Resource {
+	return &AccessPolicyResource{}
+}
+
+// AccessPolicyResource defines the resource implementation.
+type AccessPolicyResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+	cache       *ReadCache
+	policy      *GovernancePolicy
+	scim        *ScimClient
+}
+
+// AccessPolicyResourceModel describes the resource data model.
+type AccessPolicyResourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Roles    types.Map    `tfsdk:"roles"`
+	Bindings types.List   `tfsdk:"bindings"`
+	Grants   types.List   `tfsdk:"grants"`
+}
+
+type AccessPolicyRoleModel struct {
+	CollectionAction types.String `tfsdk:"collection_action"`
+	DataSourceAction types.String `tfsdk:"data_source_action"`
+}
+
+type AccessPolicyBindingModel struct {
+	Role             types.String `tfsdk:"role"`
+	GroupToken       types.String `tfsdk:"group_token"`
+	CollectionTokens types.Set    `tfsdk:"collection_tokens"`
+	DataSourceTokens types.Set    `tfsdk:"data_source_tokens"`
+}
+
+type AccessPolicyGrantModel struct {
+	TargetType      types.String `tfsdk:"target_type"`
+	TargetToken     types.String `tfsdk:"target_token"`
+	GroupToken      types.String `tfsdk:"group_token"`
+	Action          types.String `tfsdk:"action"`
+	PermissionToken types.String `tfsdk:"permission_token"`
+}
+
+var accessPolicyGrantAttrTypes = map[string]attr.Type{
+	"target_type":      types.StringType,
+	"target_token":     types.StringType,
+	"group_token":      types.StringType,
+	"action":           types.StringType,
+	"permission_token": types.StringType,
+}
+
+// actionRanks orders the actions of each target type, so that a group bound to the same target
+// through several roles receives the broadest action.
+var actionRanks = map[string]map[string]int{
+	"collection":  {"view": 1, "edit": 2},
+	"data_source": {"view": 1, "query": 2, "manage": 3},
+}
+
+func (g AccessPolicyGrantModel) key() string {
+	return g.TargetType.ValueString() + "/" + g.TargetToken.ValueString() + "/" + g.GroupToken.ValueString()
+}
+
+// Metadata sets the resource type name.
+func (r *AccessPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policy"
+}
+
+// Schema defines the resource schema.
+func (r *AccessPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Binds groups to roles over sets of collections and data sources. The roles are expanded into the full set of " +
+			"collection and data source permissions, shown in `grants`, and the permissions are reconciled against it. " +
+			"Permissions managed by a policy should not also be managed with `modeanalytics_collection_permission` or `modeanalytics_data_source_permission`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the policy, only used to identify it in Terraform",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.MapNestedAttribute{
+				MarkdownDescription: "Roles by name",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"collection_action": schema.StringAttribute{
+							MarkdownDescription: "Action granted on the collections of a binding, `view` or `edit`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"view", "edit"}...),
+							},
+						},
+						"data_source_action": schema.StringAttribute{
+							MarkdownDescription: "Action granted on the data sources of a binding, `view`, `query` or `manage`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"view", "query", "manage"}...),
+							},
+						},
+					},
+				},
+			},
+			"bindings": schema.ListNestedAttribute{
+				MarkdownDescription: "Bindings of groups to roles",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Name of the role in `roles`",
+							Required:            true,
+						},
+						"group_token": schema.StringAttribute{
+							MarkdownDescription: "Token of the group",
+							Required:            true,
+						},
+						"collection_tokens": schema.SetAttribute{
+							MarkdownDescription: "Tokens of the collections the role applies to",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"data_source_tokens": schema.SetAttribute{
+							MarkdownDescription: "Tokens of the data sources the role applies to",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Permissions the roles expand to",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target_type": schema.StringAttribute{
+							MarkdownDescription: "`collection` or `data_source`",
+							Computed:            true,
+						},
+						"target_token": schema.StringAttribute{
+							MarkdownDescription: "Token of the collection or data source",
+							Computed:            true,
+						},
+						"group_token": schema.StringAttribute{
+							MarkdownDescription: "Token of the group",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Granted action",
+							Computed:            true,
+						},
+						"permission_token": schema.StringAttribute{
+							MarkdownDescription: "Token of the permission",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *AccessPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.policy = config.Policy
+	r.cache = config.Cache
+	r.scim = config.Scim
+}
+
+// ModifyPlan expands the roles into the planned grants, keeping the permission tokens of grants that already exist.
+func (r *AccessPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if req.Plan.Raw.IsNull() || CheckScimGroups(r.scim, path.Root("bindings"), &resp.Diagnostics) {
+		return
+	}
+
+	var plan AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The policy is checked on every known binding, even while others are unknown
+	resp.Diagnostics.Append(accessPolicyViolations(ctx, plan, r.policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, known, diags := expandAccessPolicy(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("grants"), types.ListUnknown(types.ObjectType{AttrTypes: accessPolicyGrantAttrTypes}))...)
+		return
+	}
+
+	existing := map[string]AccessPolicyGrantModel{}
+	if !req.State.Raw.IsNull() {
+		var state AccessPolicyResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, grant := range grantsFromList(ctx, state.Grants, &resp.Diagnostics) {
+			existing[grant.key()] = grant
+		}
+	}
+
+	for i, grant := range desired {
+		if current, ok := existing[grant.key()]; ok {
+			desired[i].PermissionToken = current.PermissionToken
+		}
+	}
+
+	grants, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: accessPolicyGrantAttrTypes}, desired)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("grants"), grants)...)
+}
+
+// Create handles the creation of the resource.
+func (r *AccessPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan AccessPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reconcile(ctx, &plan, nil, &resp.Diagnostics)
+
+	// The state is saved even on failure, so that the grants created so far are tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource. Grants removed or changed outside of Terraform show up as drift.
+func (r *AccessPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := []AccessPolicyGrantModel{}
+	for _, grant := range grantsFromList(ctx, state.Grants, &resp.Diagnostics) {
+		listURL, embeddedKey := r.grantListURL(grant)
+		children, err := r.cache.Children(ctx, r.client, listURL, embeddedKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read permissions of %s %s, got error: %s", grant.TargetType.ValueString(), grant.TargetToken.ValueString(), err))
+			return
+		}
+
+		child, ok := children[grant.PermissionToken.ValueString()]
+		if !ok {
+			continue
+		}
+		grant.Action = types.StringValue(child.Action)
+		current = append(current, grant)
+	}
+
+	grants, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: accessPolicyGrantAttrTypes}, current)
+	resp.Diagnostics.Append(diags...)
+	state.Grants = grants
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update handles updating the resource.
+func (r *AccessPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state AccessPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := grantsFromList(ctx, state.Grants, &resp.Diagnostics)
+	r.reconcile(ctx, &plan, existing, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource.
+func (r *AccessPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state AccessPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remaining := []AccessPolicyGrantModel{}
+	for _, grant := range grantsFromList(ctx, state.Grants, &resp.Diagnostics) {
+		if err := r.deleteGrant(ctx, grant); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete permission %s, got error: %s", grant.PermissionToken.ValueString(), err))
+			remaining = append(remaining, grant)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		// Keep the grants that could not be deleted in the state
+		grants, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: accessPolicyGrantAttrTypes}, remaining)
+		resp.Diagnostics.Append(diags...)
+		state.Grants = grants
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+// reconcile creates, updates and deletes permissions until they match the grants of the plan.
+// plan.Grants is set to the grants that exist afterwards, including on failure.
+func (r *AccessPolicyResource) reconcile(ctx context.Context, plan *AccessPolicyResourceModel, existing []AccessPolicyGrantModel, diags *diag.Diagnostics) {
+	desired, _, expandDiags := expandAccessPolicy(ctx, *plan)
+	diags.Append(expandDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	existingByKey := map[string]AccessPolicyGrantModel{}
+	for _, grant := range existing {
+		existingByKey[grant.key()] = grant
+	}
+
+	applied := []AccessPolicyGrantModel{}
+	for _, grant := range desired {
+		current, ok := existingByKey[grant.key()]
+		delete(existingByKey, grant.key())
+
+		var err error
+		switch {
+		case !ok:
+			grant.PermissionToken, err = r.createGrant(ctx, grant)
+		case current.Action.ValueString() != grant.Action.ValueString():
+			grant.PermissionToken = current.PermissionToken
+			err = r.updateGrant(ctx, grant)
+		default:
+			grant.PermissionToken = current.PermissionToken
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to grant %s on %s %s to group %s, got error: %s", grant.Action.ValueString(), grant.TargetType.ValueString(), grant.TargetToken.ValueString(), grant.GroupToken.ValueString(), err))
+			if ok {
+				applied = append(applied, current)
+			}
+			continue
+		}
+		applied = append(applied, grant)
+	}
+
+	for _, grant := range existingByKey {
+		if err := r.deleteGrant(ctx, grant); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete permission %s, got error: %s", grant.PermissionToken.ValueString(), err))
+			applied = append(applied, grant)
+		}
+	}
+
+	sort.Slice(applied, func(i, j int) bool { return applied[i].key() < applied[j].key() })
+	grants, listDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: accessPolicyGrantAttrTypes}, applied)
+	diags.Append(listDiags...)
+	plan.Grants = grants
+}
+
+// grantListURL returns the URL listing the permissions of the target of a grant and the key of the list in _embedded.
+func (r *AccessPolicyResource) grantListURL(grant AccessPolicyGrantModel) (string, string) {
+	if grant.TargetType.ValueString() == "collection" {
+		return fmt.Sprintf("%s/api/%s/spaces/%s/permissions", r.modeHost, r.workspaceId, grant.TargetToken.ValueString()), "permissions"
+	}
+	return fmt.Sprintf("%s/api/%s/data_sources/%s/permissions", r.modeHost, r.workspaceId, grant.TargetToken.ValueString()), "data_source_entitlements"
+}
+
+// createGrant creates the permission of a grant and returns its token.
+func (r *AccessPolicyResource) createGrant(ctx context.Context, grant AccessPolicyGrantModel) (types.String, error) {
+	listURL, _ := r.grantListURL(grant)
+	defer r.cache.Invalidate(listURL)
+
+	var payload interface{}
+	if grant.TargetType.ValueString() == "collection" {
+		payload = CollectionPermissionPayload{
+			Permission: CollectionPermission{
+				Action:        grant.Action.ValueString(),
+				AccessorType:  "UserGroup",
+				AccessorToken: grant.GroupToken.ValueString(),
+			},
+		}
+	} else {
+		payload = DataSourcePermissionPayload{
+			Permission: Permission{
+				Action:        grant.Action.ValueString(),
+				AccessorType:  "UserGroup",
+				AccessorToken: grant.GroupToken.ValueString(),
+			},
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, listURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return types.StringNull(), fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	var responseData struct {
+		PermissionToken string `json:"token"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&responseData); err != nil {
+		return types.StringNull(), fmt.Errorf("error parsing response: %s", err)
+	}
+	return types.StringValue(responseData.PermissionToken), nil
+}
+
+// updateGrant changes the action of the permission of a grant.
+func (r *AccessPolicyResource) updateGrant(ctx context.Context, grant AccessPolicyGrantModel) error {
+	listURL, _ := r.grantListURL(grant)
+	defer r.cache.Invalidate(listURL)
+
+	var payload interface{}
+	if grant.TargetType.ValueString() == "collection" {
+		payload = CollectionPermissionUpdatePayload{
+			Permission: UpdateCollectionPermission{
+				Action: grant.Action.ValueString(),
+			},
+		}
+	} else {
+		payload = DataSourcePermissionUpdatePayload{
+			Permission: UpdatePermission{
+				Action: grant.Action.ValueString(),
+			},
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := listURL + "/" + grant.PermissionToken.ValueString()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// deleteGrant deletes the permission of a grant. A permission that no longer exists is not an error.
+func (r *AccessPolicyResource) deleteGrant(ctx context.Context, grant AccessPolicyGrantModel) error {
+	listURL, _ := r.grantListURL(grant)
+	defer r.cache.Invalidate(listURL)
+
+	url := listURL + "/" + grant.PermissionToken.ValueString()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// expandAccessPolicy returns the grants the bindings of a policy expand to, sorted by target and group.
+// The returned bool is false if the roles or bindings still contain unknown values.
+func expandAccessPolicy(ctx context.Context, policy AccessPolicyResourceModel) ([]AccessPolicyGrantModel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	grants := map[string]AccessPolicyGrantModel{}
+
+	// Roles and bindings built from the outputs of other resources may be unknown as a whole or per element
+	if policy.Roles.IsUnknown() || policy.Bindings.IsUnknown() {
+		return nil, false, diags
+	}
+	for _, role := range policy.Roles.Elements() {
+		if role.IsUnknown() {
+			return nil, false, diags
+		}
+	}
+	for _, binding := range policy.Bindings.Elements() {
+		if binding.IsUnknown() {
+			return nil, false, diags
+		}
+	}
+
+	roles := map[string]AccessPolicyRoleModel{}
+	var bindings []AccessPolicyBindingModel
+	diags.Append(policy.Roles.ElementsAs(ctx, &roles, false)...)
+	diags.Append(policy.Bindings.ElementsAs(ctx, &bindings, false)...)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	for i, binding := range bindings {
+		if binding.Role.IsUnknown() || binding.GroupToken.IsUnknown() || binding.CollectionTokens.IsUnknown() || binding.DataSourceTokens.IsUnknown() {
+			return nil, false, diags
+		}
+
+		role, ok := roles[binding.Role.ValueString()]
+		if !ok {
+			diags.AddAttributeError(path.Root("bindings").AtListIndex(i).AtName("role"), "Unknown Role", fmt.Sprintf("Role %q is not defined in roles", binding.Role.ValueString()))
+			continue
+		}
+
+		targets := []struct {
+			targetType string
+			action     types.String
+			tokens     types.Set
+			attribute  string
+		}{
+			{"collection", role.CollectionAction, binding.CollectionTokens, "collection_tokens"},
+			{"data_source", role.DataSourceAction, binding.DataSourceTokens, "data_source_tokens"},
+		}
+		for _, target := range targets {
+			if target.tokens.IsNull() {
+				continue
+			}
+			var tokens []types.String
+			diags.Append(target.tokens.ElementsAs(ctx, &tokens, false)...)
+			if len(tokens) == 0 {
+				continue
+			}
+			if target.action.IsNull() {
+				diags.AddAttributeError(
+					path.Root("bindings").AtListIndex(i).AtName(target.attribute),
+					"Role Without Action",
+					fmt.Sprintf("Role %q grants no %s action, so the binding cannot list %s", binding.Role.ValueString(), target.targetType, target.attribute),
+				)
+				continue
+			}
+
+			for _, token := range tokens {
+				if token.IsUnknown() {
+					return nil, false, diags
+				}
+				grant := AccessPolicyGrantModel{
+					TargetType:      types.StringValue(target.targetType),
+					TargetToken:     token,
+					GroupToken:      binding.GroupToken,
+					Action:          target.action,
+					PermissionToken: types.StringUnknown(),
+				}
+				ranks := actionRanks[target.targetType]
+				if existing, ok := grants[grant.key()]; ok && ranks[existing.Action.ValueString()] >= ranks[grant.Action.ValueString()] {
+					continue
+				}
+				grants[grant.key()] = grant
+			}
+		}
+	}
+
+	expanded := make([]AccessPolicyGrantModel, 0, len(grants))
+	for _, grant := range grants {
+		expanded = append(expanded, grant)
+	}
+	sort.Slice(expanded, func(i, j int) bool { return expanded[i].key() < expanded[j].key() })
+	return expanded, true, diags
+}
+
+// accessPolicyViolations reports the provider policy rules broken by the bindings of policy. A binding is
+// checked once its role is known, and the rules on its group once the group token is known too.
+func accessPolicyViolations(ctx context.Context, policy AccessPolicyResourceModel, governance *GovernancePolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if governance == nil || policy.Roles.IsUnknown() || policy.Bindings.IsUnknown() {
+		return diags
+	}
+
+	for i, element := range policy.Bindings.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() || object.IsNull() {
+			continue
+		}
+		var binding AccessPolicyBindingModel
+		bindingDiags := object.As(ctx, &binding, basetypes.ObjectAsOptions{})
+		diags.Append(bindingDiags...)
+		if bindingDiags.HasError() || binding.Role.IsUnknown() {
+			continue
+		}
+
+		roleElement, ok := policy.Roles.Elements()[binding.Role.ValueString()].(types.Object)
+		if !ok || roleElement.IsUnknown() {
+			continue
+		}
+		var role AccessPolicyRoleModel
+		roleDiags := roleElement.As(ctx, &role, basetypes.ObjectAsOptions{})
+		diags.Append(roleDiags...)
+		if roleDiags.HasError() {
+			continue
+		}
+
+		var violations []string
+		if hasTokens(binding.CollectionTokens) && !role.CollectionAction.IsNull() && !role.CollectionAction.IsUnknown() {
+			violations = append(violations, governance.CollectionPermissionViolations("UserGroup", role.CollectionAction.ValueString())...)
+		}
+		if hasTokens(binding.DataSourceTokens) && !role.DataSourceAction.IsNull() && !role.DataSourceAction.IsUnknown() {
+			violations = append(violations, governance.DataSourcePermissionViolations("UserGroup", role.DataSourceAction.ValueString())...)
+			if !binding.GroupToken.IsUnknown() {
+				violations = append(violations, governance.DataSourceManageViolations("UserGroup", binding.GroupToken.ValueString(), role.DataSourceAction.ValueString())...)
+			}
+		}
+		for _, violation := range violations {
+			diags.AddAttributeError(path.Root("bindings").AtListIndex(i), "Policy Violation",
+				fmt.Sprintf("The provider policy does not allow the grants of role %q: %s", binding.Role.ValueString(), violation))
+		}
+	}
+	return diags
+}
+
+// hasTokens reports whether tokens may hold a token, which is the case unless it is null or known to be empty.
+func hasTokens(tokens types.Set) bool {
+	return tokens.IsUnknown() || len(tokens.Elements()) > 0
+}
+
+// grantsFromList converts the grants attribute into grant models. A null or unknown list has no grants.
+func grantsFromList(ctx context.Context, list types.List, diags *diag.Diagnostics) []AccessPolicyGrantModel {
+	grants := []AccessPolicyGrantModel{}
+	if list.IsNull() || list.IsUnknown() {
+		return grants
+	}
+	diags.Append(list.ElementsAs(ctx, &grants, false)...)
+	return grants
+}
@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApiTokenResource{}
+var _ resource.ResourceWithModifyPlan = &ApiTokenResource{}
+
+// NewApiTokenResource returns a new instance of ApiTokenResource.
+func NewApiTokenResource() resource.Resource {
+	return &ApiTokenResource{}
+}
+
+// ApiTokenResource defines the resource implementation.
+type ApiTokenResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ApiTokenResourceModel describes the resource data model.
+type ApiTokenResourceModel struct {
+	ApiTokenToken types.String `tfsdk:"api_token_token"`
+	Name          types.String `tfsdk:"name"`
+	Scopes        types.Set    `tfsdk:"scopes"`
+	RotateAfter   types.String `tfsdk:"rotate_after"`
+	RotateAt      types.String `tfsdk:"rotate_at"`
+	Secret        types.String `tfsdk:"secret"`
+	State         types.String `tfsdk:"state"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+}
+
+type ApiToken struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type ApiTokenPayload struct {
+	ApiToken ApiToken `json:"api_token"`
+}
+
+// Metadata sets the resource type name.
+func (r *ApiTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+// Schema defines the resource schema.
+func (r *ApiTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Workspace API token for service accounts. The secret is only returned when the token is created. " +
+			"Once `rotate_after` has elapsed the next plan replaces the token; use `create_before_destroy` so consumers can switch to the new token first.",
+
+		Attributes: map[string]schema.Attribute{
+			"api_token_token": schema.StringAttribute{
+				MarkdownDescription: "Token identifying the API token, used as the username for basic authentication",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the API token",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes granted to the API token. All scopes are granted when unset",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "How long after creation the token is rotated, as a Go duration string such as `720h`",
+				Optional:            true,
+				Validators: []validator.String{
+					DurationValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_at": schema.StringAttribute{
+				MarkdownDescription: "Time after which the next plan rotates the token",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Secret of the API token. Only known for tokens created by Terraform",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the API token",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the token was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ApiTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan replaces the token once its rotation time has passed.
+func (r *ApiTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	// Nothing to rotate on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state ApiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotateAt := parseModeTime(state.RotateAt.ValueString())
+	if rotateAt.IsZero() || time.Now().Before(rotateAt) {
+		return
+	}
+
+	// Terraform only replaces a resource when a path marked as requiring replacement changes,
+	// so rotate_at is planned as unknown to force the new token.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotate_at"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rotate_at"))
+}
+
+// Create handles the creation of the resource.
+func (r *ApiTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ApiTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload := ApiTokenPayload{
+		ApiToken: ApiToken{
+			Name: plan.Name.ValueString(),
+		},
+	}
+	if !plan.Scopes.IsNull() {
+		resp.Diagnostics.Append(plan.Scopes.ElementsAs(ctx, &payload.ApiToken.Scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/api_tokens", r.modeHost, r.workspaceId)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API token, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API token, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData struct {
+		ApiTokenToken string `json:"token"`
+		Secret        string `json:"secret"`
+		State         string `json:"state"`
+		CreatedAt     string `json:"created_at"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.ApiTokenToken = types.StringValue(responseData.ApiTokenToken)
+	plan.Secret = types.StringValue(responseData.Secret)
+	plan.State = types.StringValue(responseData.State)
+	plan.CreatedAt = types.StringValue(responseData.CreatedAt)
+	plan.RotateAt = types.StringNull()
+
+	if !plan.RotateAfter.IsNull() {
+		rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Rotate After", fmt.Sprintf("Unable to parse rotate_after %q: %s", plan.RotateAfter.ValueString(), err))
+			return
+		}
+		createdAt := parseModeTime(responseData.CreatedAt)
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+		plan.RotateAt = types.StringValue(createdAt.Add(rotateAfter).UTC().Format(time.RFC3339))
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource. The secret is never returned again, so it is kept from the state.
+func (r *ApiTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ApiTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/api_tokens/%s", r.modeHost, r.workspaceId, state.ApiTokenToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API token, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API token, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData struct {
+			Name      string `json:"name"`
+			State     string `json:"state"`
+			CreatedAt string `json:"created_at"`
+		}
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+		// A token revoked in the UI has to be recreated
+		if responseData.State == "revoked" || responseData.State == "soft_deleted" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.State = types.StringValue(responseData.State)
+		state.CreatedAt = types.StringValue(responseData.CreatedAt)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource. Every argument forces a new token, so there is nothing to send.
+func (r *ApiTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ApiTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete revokes the API token.
+func (r *ApiTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state ApiTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/api_tokens/%s", r.modeHost, r.workspaceId, state.ApiTokenToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke API token, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || (httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke API token, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ApiTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("api_token_token"), req.ID)...)
+}
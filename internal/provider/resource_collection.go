@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,11 +15,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CollectionResource{}
+var _ resource.ResourceWithModifyPlan = &CollectionResource{}
+var _ resource.ResourceWithValidateConfig = &CollectionResource{}
 
 // NewCollectionResource returns a new instance of CollectionResource.
 func NewCollectionResource() resource.Resource {
@@ -30,6 +34,26 @@ type CollectionResource struct {
 	client      *http.Client
 	modeHost    string
 	workspaceId string
+	readOnly    bool
+	policy      *GovernancePolicy
+}
+
+// CollectionResourceModel describes the resource data model.
+type CollectionResourceModel struct {
+	Name               types.String `tfsdk:"name"`
+	State              types.String `tfsdk:"state"`
+	CollectionToken    types.String `tfsdk:"collection_token"`
+	CollectionType     types.String `tfsdk:"collection_type"`
+	Id                 types.String `tfsdk:"id"`
+	Description        types.String `tfsdk:"description"`
+	Restricted         types.Bool   `tfsdk:"restricted"`
+	FreeDefault        types.Bool   `tfsdk:"free_default"`
+	Viewable           types.Bool   `tfsdk:"viewable"`
+	DefaultAccessLevel types.String `tfsdk:"default_access_level"`
+	RestoreSoftDeleted types.Bool   `tfsdk:"restore_soft_deleted"`
+	OnDestroy          types.String `tfsdk:"on_destroy"`
+	MoveReportsTo      types.String `tfsdk:"move_reports_to"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type Collection struct {
@@ -113,10 +137,55 @@ func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 				Default:             stringdefault.StaticString("restricted"),
 			},
+			"restore_soft_deleted": schema.BoolAttribute{
+				MarkdownDescription: "Restore the collection with its existing token if it was deleted outside of Terraform, instead of creating a new one",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the reports of the collection when it is destroyed. " +
+					"`fail_if_not_empty` (default) refuses to destroy a collection that still has reports, " +
+					"`delete` permanently deletes every report of the collection with it, " +
+					"`move_reports` moves them to `move_reports_to` before deleting the collection and " +
+					"`archive` archives them and leaves the collection in place, removing it from the state only",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("fail_if_not_empty"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"delete", "fail_if_not_empty", "move_reports", "archive"}...),
+				},
+			},
+			"move_reports_to": schema.StringAttribute{
+				MarkdownDescription: "Token of the collection that receives the reports when `on_destroy` is `move_reports`",
+				Optional:            true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the collection from being destroyed or replaced until this is set to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+// ValidateConfig checks that move_reports_to is set exactly when reports are moved on destroy.
+func (r *CollectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CollectionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.OnDestroy.IsUnknown() || config.MoveReportsTo.IsUnknown() {
+		return
+	}
+
+	if config.OnDestroy.ValueString() == "move_reports" && config.MoveReportsTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("move_reports_to"), "Missing Attribute", "move_reports_to is required when on_destroy is \"move_reports\"")
+	}
+	if config.OnDestroy.ValueString() != "move_reports" && !config.MoveReportsTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("move_reports_to"), "Invalid Attribute Combination", "move_reports_to can only be set when on_destroy is \"move_reports\"")
+	}
+}
+
 // Configure sets the resource client.
 func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -127,6 +196,10 @@ func (r *CollectionResource) Configure(ctx context.Context, req resource.Configu
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	})
 
 	if !ok {
@@ -140,11 +213,39 @@ func (r *CollectionResource) Configure(ctx context.Context, req resource.Configu
 	r.client = config.Client
 	r.modeHost = config.ModeHost
 	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.policy = config.Policy
+}
+
+// ModifyPlan plans the restore of a soft-deleted collection and enforces the provider policy.
+func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	PlanSoftDeletedRestore(ctx, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+	var restricted types.Bool
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("restricted"), &restricted)...)
+	if name.IsUnknown() || restricted.IsUnknown() {
+		return
+	}
+	for _, violation := range r.policy.CollectionViolations(name.ValueString(), restricted.ValueBool()) {
+		resp.Diagnostics.AddError("Policy Violation", fmt.Sprintf("The provider policy does not allow this collection: %s", violation))
+	}
 }
 
 // Create handles the creation of the resource.
 func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan CollectionModel
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan CollectionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -217,7 +318,7 @@ func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequ
 
 // Read handles reading the resource.
 func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state CollectionModel
+	var state CollectionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -256,7 +357,7 @@ func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
 			return
 		}
-		if responseData.State == "soft_deleted" {
+		if responseData.State == "soft_deleted" && !state.RestoreSoftDeleted.ValueBool() {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -305,14 +406,27 @@ func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update handles updating the resource.
 func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan CollectionModel
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state CollectionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	url := fmt.Sprintf("%s/api/%s/spaces/%s", r.modeHost, r.workspaceId, plan.CollectionToken.ValueString())
+
+	if state.State.ValueString() == "soft_deleted" && plan.RestoreSoftDeleted.ValueBool() {
+		if err := RestoreSoftDeleted(ctx, r.client, url); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore collection, got error: %s", err))
+			return
+		}
+	}
+
 	payload := CollectionPayload{
 		Collection: Collection{
 			CollectionType:     plan.CollectionType.ValueString(),
@@ -371,13 +485,81 @@ func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequ
 
 // Delete handles deleting the resource.
 func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state CollectionModel
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state CollectionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if CheckDeletionProtection(state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	// State written before on_destroy existed takes the default
+	onDestroy := state.OnDestroy.ValueString()
+	if onDestroy == "" {
+		onDestroy = "fail_if_not_empty"
+	}
+
+	// Reports are dealt with before the collection is deleted, as the DELETE takes them with it
+	if onDestroy != "delete" {
+		reports, err := r.listReports(ctx, state.CollectionToken.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list reports of collection, got error: %s", err))
+			return
+		}
+
+		switch onDestroy {
+		case "fail_if_not_empty":
+			if len(reports) > 0 {
+				resp.Diagnostics.AddError(
+					"Collection Not Empty",
+					fmt.Sprintf("Collection %s still contains %d report(s) and on_destroy is \"fail_if_not_empty\". Move or delete the reports first.", state.CollectionToken.ValueString(), len(reports)),
+				)
+				return
+			}
+		case "move_reports":
+			for _, reportToken := range reports {
+				if err := r.moveReport(ctx, reportToken, state.MoveReportsTo.ValueString()); err != nil {
+					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to move report %s to collection %s, got error: %s", reportToken, state.MoveReportsTo.ValueString(), err))
+					return
+				}
+			}
+
+			// Reports added while moving would otherwise be deleted with the collection
+			remaining, err := r.listReports(ctx, state.CollectionToken.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list reports of collection, got error: %s", err))
+				return
+			}
+			if len(remaining) > 0 {
+				resp.Diagnostics.AddError(
+					"Collection Not Empty",
+					fmt.Sprintf("Collection %s still contains %d report(s) after moving its reports to %s, so it was not deleted. Destroy again to move them.", state.CollectionToken.ValueString(), len(remaining), state.MoveReportsTo.ValueString()),
+				)
+				return
+			}
+		case "archive":
+			for _, reportToken := range reports {
+				if err := r.archiveReport(ctx, reportToken); err != nil {
+					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to archive report %s, got error: %s", reportToken, err))
+					return
+				}
+			}
+			resp.Diagnostics.AddWarning(
+				"Collection Not Deleted",
+				fmt.Sprintf("The %d report(s) of collection %s were archived. The collection was removed from the state but left in Mode so the reports stay recoverable.", len(reports), state.CollectionToken.ValueString()),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
 	url := fmt.Sprintf("%s/api/%s/spaces/%s", r.modeHost, r.workspaceId, state.CollectionToken.ValueString())
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -403,6 +585,68 @@ func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 	resp.State.RemoveResource(ctx)
 }
 
+// listReports returns the tokens of every report of a collection. Pages are read until one adds no report.
+func (r *CollectionResource) listReports(ctx context.Context, collectionToken string) ([]string, error) {
+	tokens := []string{}
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		var responseData struct {
+			Embedded struct {
+				Reports []struct {
+					ReportToken string `json:"token"`
+				} `json:"reports"`
+			} `json:"_embedded"`
+		}
+		url := fmt.Sprintf("%s/api/%s/spaces/%s/reports?page=%d", r.modeHost, r.workspaceId, collectionToken, page)
+		if err := HttpGetJSON(ctx, r.client, url, &responseData); err != nil {
+			return nil, err
+		}
+
+		added := false
+		for _, report := range responseData.Embedded.Reports {
+			if !seen[report.ReportToken] {
+				seen[report.ReportToken] = true
+				tokens = append(tokens, report.ReportToken)
+				added = true
+			}
+		}
+		if !added {
+			return tokens, nil
+		}
+	}
+}
+
+// moveReport moves a report to another collection.
+func (r *CollectionResource) moveReport(ctx context.Context, reportToken string, collectionToken string) error {
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, reportToken)
+	jsonBody, _ := json.Marshal(map[string]map[string]string{"report": {"space_token": collectionToken}})
+	return r.patchReport(ctx, url, jsonBody)
+}
+
+// archiveReport archives a report.
+func (r *CollectionResource) archiveReport(ctx context.Context, reportToken string) error {
+	url := fmt.Sprintf("%s/api/%s/reports/%s/archive", r.modeHost, r.workspaceId, reportToken)
+	return r.patchReport(ctx, url, nil)
+}
+
+func (r *CollectionResource) patchReport(ctx context.Context, url string, jsonBody []byte) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
 func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_token"), req.ID)...)
 }
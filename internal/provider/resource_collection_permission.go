@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -20,6 +21,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CollectionPermissionResource{}
+var _ resource.ResourceWithModifyPlan = &CollectionPermissionResource{}
 
 // NewCollectionPermissionResource returns a new instance of CollectionPermissionResource.
 func NewCollectionPermissionResource() resource.Resource {
@@ -31,15 +33,21 @@ type CollectionPermissionResource struct {
 	client      *http.Client
 	modeHost    string
 	workspaceId string
+	readOnly    bool
+	cache       *ReadCache
+	policy      *GovernancePolicy
+	scim        *ScimClient
 }
 
 // CollectionPermissionResourceModel describes the resource data model.
 type CollectionPermissionResourceModel struct {
-	CollectionToken types.String `tfsdk:"collection_token"`
-	Action          types.String `tfsdk:"action"`
-	AccessorToken   types.String `tfsdk:"accessor_token"`
-	AccessorType    types.String `tfsdk:"accessor_type"`
-	PermissionToken types.String `tfsdk:"permission_token"`
+	CollectionToken    types.String `tfsdk:"collection_token"`
+	Action             types.String `tfsdk:"action"`
+	AccessorToken      types.String `tfsdk:"accessor_token"`
+	AccessorType       types.String `tfsdk:"accessor_type"`
+	MemberUsername     types.String `tfsdk:"member_username"`
+	PermissionToken    types.String `tfsdk:"permission_token"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type CollectionPermission struct {
@@ -82,7 +90,19 @@ func (r *CollectionPermissionResource) Schema(ctx context.Context, req resource.
 				},
 			},
 			"accessor_token": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("member_username")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_username": schema.StringAttribute{
+				MarkdownDescription: "Username of the member to grant the permission to, resolved to `accessor_token` during planning. Requires `accessor_type` to be `Account`",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -104,6 +124,12 @@ func (r *CollectionPermissionResource) Schema(ctx context.Context, req resource.
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the permission from being destroyed or replaced until this is set to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -118,6 +144,10 @@ func (r *CollectionPermissionResource) Configure(ctx context.Context, req resour
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	})
 
 	if !ok {
@@ -131,10 +161,61 @@ func (r *CollectionPermissionResource) Configure(ctx context.Context, req resour
 	r.client = config.Client
 	r.modeHost = config.ModeHost
 	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.policy = config.Policy
+	r.cache = config.Cache
+	r.scim = config.Scim
+}
+
+// ModifyPlan resolves member_username to the accessor token, checks that the referenced objects exist
+// and enforces the provider policy.
+func (r *CollectionPermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan CollectionPermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.MemberUsername.IsNull() && plan.AccessorType.ValueString() == "UserGroup" {
+		resp.Diagnostics.AddAttributeError(path.Root("member_username"), "Invalid Accessor Type", "member_username can only be used with accessor_type Account")
+		return
+	}
+	if plan.AccessorType.ValueString() == "UserGroup" && CheckScimGroups(r.scim, path.Root("accessor_type"), &resp.Diagnostics) {
+		return
+	}
+
+	ResolveMemberUsernamePlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp, path.Root("member_username"), path.Root("accessor_token"))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ValidateReferencePlan(ctx, r.client, req, resp, path.Root("collection_token"), "collection", r.modeHost+"/api/"+r.workspaceId+"/spaces/%s")
+	ValidateAccessorPlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp)
+
+	// Governance rules do not depend on the accessor_token, which may only be known during the apply
+	var accessorType, action types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("accessor_type"), &accessorType)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("action"), &action)...)
+	if accessorType.IsUnknown() || action.IsUnknown() {
+		return
+	}
+	for _, violation := range r.policy.CollectionPermissionViolations(accessorType.ValueString(), action.ValueString()) {
+		resp.Diagnostics.AddError("Policy Violation", fmt.Sprintf("The provider policy does not allow this permission: %s", violation))
+	}
 }
 
 // Create handles the creation of the resource.
 func (r *CollectionPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan CollectionPermissionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -142,6 +223,8 @@ func (r *CollectionPermissionResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(plan.CollectionToken.ValueString()))
+
 	url := fmt.Sprintf("%s/api/%s/spaces/%s/permissions", r.modeHost, r.workspaceId, plan.CollectionToken.ValueString())
 
 	payload := CollectionPermissionPayload{
@@ -190,44 +273,35 @@ func (r *CollectionPermissionResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	url := fmt.Sprintf("%s/api/%s/spaces/%s/permissions/%s", r.modeHost, r.workspaceId, state.CollectionToken.ValueString(), state.PermissionToken.ValueString())
-	httpReq, err := http.NewRequest("GET", url, nil)
+	// The list of the parent is shared by all collection permissions read during this run
+	children, err := r.cache.Children(ctx, r.client, r.listURL(state.CollectionToken.ValueString()), "permissions")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection permission, got error: %s", err))
 		return
 	}
 
-	httpResp, err := HttpRetry(r.client, httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection permission, got error: %s", err))
+	child, ok := children[state.PermissionToken.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	defer httpResp.Body.Close()
-
-	var responseData struct {
-		PermissionToken string `json:"token"`
-		Action          string `json:"action"`
-	}
 
-	if httpResp.StatusCode == http.StatusOK {
-		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
-			return
-		}
+	state.Action = types.StringValue(child.Action)
 
-		state.Action = types.StringValue(responseData.Action)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	} else if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-	} else {
-		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
-	}
+// listURL returns the URL listing the permissions of a parent, which is also the key of its cached list.
+func (r *CollectionPermissionResource) listURL(parentToken string) string {
+	return fmt.Sprintf("%s/api/%s/spaces/%s/permissions", r.modeHost, r.workspaceId, parentToken)
 }
 
 // Update handles updating the resource.
 func (r *CollectionPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan CollectionPermissionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -235,6 +309,8 @@ func (r *CollectionPermissionResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(plan.CollectionToken.ValueString()))
+
 	url := fmt.Sprintf("%s/api/%s/spaces/%s/permissions/%s", r.modeHost, r.workspaceId, plan.CollectionToken.ValueString(), plan.PermissionToken.ValueString())
 	payload := CollectionPermissionUpdatePayload{
 		Permission: UpdateCollectionPermission{
@@ -274,6 +350,10 @@ func (r *CollectionPermissionResource) Update(ctx context.Context, req resource.
 
 // Delete handles deleting the resource.
 func (r *CollectionPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var state CollectionPermissionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -281,6 +361,12 @@ func (r *CollectionPermissionResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(state.CollectionToken.ValueString()))
+
+	if CheckDeletionProtection(state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/spaces/%s/permissions/%s", r.modeHost, r.workspaceId, state.CollectionToken.ValueString(), state.PermissionToken.ValueString())
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
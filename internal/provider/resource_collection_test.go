@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func testReports(tokens ...string) map[string]interface{} {
+	reports := []map[string]string{}
+	for _, token := range tokens {
+		reports = append(reports, map[string]string{"token": token})
+	}
+	return map[string]interface{}{"_embedded": map[string]interface{}{"reports": reports}}
+}
+
+func TestCollectionListReports(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"GET /api/ws/spaces/c1/reports?page=1": testReports("r1", "r2"),
+		"GET /api/ws/spaces/c1/reports?page=2": testReports("r3"),
+		"GET /api/ws/spaces/c1/reports?page=3": testReports(),
+		// The last page is served again past the end
+		"GET /api/ws/spaces/c2/reports?page=1": testReports("r4"),
+		"GET /api/ws/spaces/c2/reports?page=2": testReports("r4"),
+	})
+	r := &CollectionResource{client: http.DefaultClient, modeHost: standIn.URL, workspaceId: "ws"}
+
+	for collection, want := range map[string][]string{"c1": {"r1", "r2", "r3"}, "c2": {"r4"}} {
+		tokens, err := r.listReports(ctx, collection)
+		if err != nil {
+			t.Fatalf("listReports(%s): %s", collection, err)
+		}
+		if !reflect.DeepEqual(tokens, want) {
+			t.Errorf("listReports(%s) = %v, want %v", collection, tokens, want)
+		}
+	}
+
+	if _, err := r.listReports(ctx, "missing"); err == nil {
+		t.Errorf("listReports of a missing collection succeeded")
+	}
+}
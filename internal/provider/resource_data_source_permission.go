@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -20,6 +21,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DataSourcePermissionResource{}
+var _ resource.ResourceWithModifyPlan = &DataSourcePermissionResource{}
 
 // NewDataSourcePermissionResource returns a new instance of DataSourcePermissionResource.
 func NewDataSourcePermissionResource() resource.Resource {
@@ -31,15 +33,21 @@ type DataSourcePermissionResource struct {
 	client      *http.Client
 	modeHost    string
 	workspaceId string
+	readOnly    bool
+	cache       *ReadCache
+	policy      *GovernancePolicy
+	scim        *ScimClient
 }
 
 // DataSourcePermissionResourceModel describes the resource data model.
 type DataSourcePermissionResourceModel struct {
-	DataSourceToken types.String `tfsdk:"data_source_token"`
-	Action          types.String `tfsdk:"action"`
-	AccessorToken   types.String `tfsdk:"accessor_token"`
-	AccessorType    types.String `tfsdk:"accessor_type"`
-	PermissionToken types.String `tfsdk:"permission_token"`
+	DataSourceToken    types.String `tfsdk:"data_source_token"`
+	Action             types.String `tfsdk:"action"`
+	AccessorToken      types.String `tfsdk:"accessor_token"`
+	AccessorType       types.String `tfsdk:"accessor_type"`
+	MemberUsername     types.String `tfsdk:"member_username"`
+	PermissionToken    types.String `tfsdk:"permission_token"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type Permission struct {
@@ -82,7 +90,19 @@ func (r *DataSourcePermissionResource) Schema(ctx context.Context, req resource.
 				},
 			},
 			"accessor_token": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("member_username")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_username": schema.StringAttribute{
+				MarkdownDescription: "Username of the member to grant the permission to, resolved to `accessor_token` during planning. Requires `accessor_type` to be `Account`",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -104,6 +124,12 @@ func (r *DataSourcePermissionResource) Schema(ctx context.Context, req resource.
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the permission from being destroyed or replaced until this is set to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -118,6 +144,10 @@ func (r *DataSourcePermissionResource) Configure(ctx context.Context, req resour
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	})
 
 	if !ok {
@@ -131,10 +161,67 @@ func (r *DataSourcePermissionResource) Configure(ctx context.Context, req resour
 	r.client = config.Client
 	r.modeHost = config.ModeHost
 	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.policy = config.Policy
+	r.cache = config.Cache
+	r.scim = config.Scim
+}
+
+// ModifyPlan resolves member_username to the accessor token, checks that the referenced objects exist
+// and enforces the provider policy.
+func (r *DataSourcePermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DataSourcePermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.MemberUsername.IsNull() && plan.AccessorType.ValueString() == "UserGroup" {
+		resp.Diagnostics.AddAttributeError(path.Root("member_username"), "Invalid Accessor Type", "member_username can only be used with accessor_type Account")
+		return
+	}
+	if plan.AccessorType.ValueString() == "UserGroup" && CheckScimGroups(r.scim, path.Root("accessor_type"), &resp.Diagnostics) {
+		return
+	}
+
+	ResolveMemberUsernamePlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp, path.Root("member_username"), path.Root("accessor_token"))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ValidateReferencePlan(ctx, r.client, req, resp, path.Root("data_source_token"), "data source", r.modeHost+"/api/"+r.workspaceId+"/data_sources/%s")
+	ValidateAccessorPlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp)
+
+	// Governance rules on the accessor type and action are checked as soon as both are known, and those
+	// on the resolved accessor_token once it is, which may only be during the apply
+	var accessorType, accessorToken, action types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("accessor_type"), &accessorType)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("accessor_token"), &accessorToken)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("action"), &action)...)
+	if accessorType.IsUnknown() || action.IsUnknown() {
+		return
+	}
+	violations := r.policy.DataSourcePermissionViolations(accessorType.ValueString(), action.ValueString())
+	if !accessorToken.IsUnknown() {
+		violations = append(violations, r.policy.DataSourceManageViolations(accessorType.ValueString(), accessorToken.ValueString(), action.ValueString())...)
+	}
+	for _, violation := range violations {
+		resp.Diagnostics.AddError("Policy Violation", fmt.Sprintf("The provider policy does not allow this permission: %s", violation))
+	}
 }
 
 // Create handles the creation of the resource.
 func (r *DataSourcePermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan DataSourcePermissionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -142,6 +229,8 @@ func (r *DataSourcePermissionResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(plan.DataSourceToken.ValueString()))
+
 	url := fmt.Sprintf("%s/api/%s/data_sources/%s/permissions", r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString())
 
 	payload := DataSourcePermissionPayload{
@@ -190,93 +279,35 @@ func (r *DataSourcePermissionResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	url := fmt.Sprintf("%s/api/%s/data_sources/%s/permissions/%s", r.modeHost, r.workspaceId, state.DataSourceToken.ValueString(), state.PermissionToken.ValueString())
-	httpReq, err := http.NewRequest("GET", url, nil)
+	// The list of the parent is shared by all data source permissions read during this run
+	children, err := r.cache.Children(ctx, r.client, r.listURL(state.DataSourceToken.ValueString()), "data_source_entitlements")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source permission, got error: %s", err))
 		return
 	}
 
-	httpResp, err := HttpRetry(r.client, httpReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source permission, got error: %s", err))
+	child, ok := children[state.PermissionToken.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	var responseData struct {
-		PermissionToken string `json:"token"`
-		Action          string `json:"action"`
-	}
-
-	if httpResp.StatusCode == http.StatusOK {
-		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
-			return
-		}
+	state.Action = types.StringValue(child.Action)
 
-		state.Action = types.StringValue(responseData.Action)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	} else if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-	} else if httpResp.StatusCode == http.StatusInternalServerError {
-
-		list_url := fmt.Sprintf("%s/api/%s/data_sources/%s/permissions", r.modeHost, r.workspaceId, state.DataSourceToken.ValueString())
-
-		listHttpReq, err := http.NewRequest("GET", list_url, nil)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source permission, got error: %s", err))
-			return
-		}
-		listHttpResp, err := HttpRetry(r.client, listHttpReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source permission, got error: %s", err))
-			return
-		}
-		defer listHttpResp.Body.Close()
-
-		var listResponseData struct {
-			Embedded struct {
-				Entitlements []struct {
-					PermissionToken string `json:"token"`
-					Action          string `json:"action"`
-				} `json:"data_source_entitlements"`
-			} `json:"_embedded"`
-		}
-
-		if listHttpResp.StatusCode == http.StatusOK {
-			err = json.NewDecoder(listHttpResp.Body).Decode(&listResponseData)
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
-				return
-			}
-
-			var found bool
-
-			for _, entitlement := range listResponseData.Embedded.Entitlements {
-				if entitlement.PermissionToken == state.PermissionToken.ValueString() {
-					state.Action = types.StringValue(entitlement.Action)
-					resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				resp.State.RemoveResource(ctx)
-			}
-		} else {
-			resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", listHttpResp.StatusCode))
-		}
-	} else {
-		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
-	}
+// listURL returns the URL listing the permissions of a parent, which is also the key of its cached list.
+func (r *DataSourcePermissionResource) listURL(parentToken string) string {
+	return fmt.Sprintf("%s/api/%s/data_sources/%s/permissions", r.modeHost, r.workspaceId, parentToken)
 }
 
 // Update handles updating the resource.
 func (r *DataSourcePermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan DataSourcePermissionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -284,6 +315,8 @@ func (r *DataSourcePermissionResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(plan.DataSourceToken.ValueString()))
+
 	url := fmt.Sprintf("%s/api/%s/data_sources/%s/permissions/%s", r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString(), plan.PermissionToken.ValueString())
 	payload := DataSourcePermissionUpdatePayload{
 		Permission: UpdatePermission{
@@ -323,6 +356,10 @@ func (r *DataSourcePermissionResource) Update(ctx context.Context, req resource.
 
 // Delete handles deleting the resource.
 func (r *DataSourcePermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var state DataSourcePermissionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -330,6 +367,12 @@ func (r *DataSourcePermissionResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(state.DataSourceToken.ValueString()))
+
+	if CheckDeletionProtection(state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/data_sources/%s/permissions/%s", r.modeHost, r.workspaceId, state.DataSourceToken.ValueString(), state.PermissionToken.ValueString())
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -0,0 +1,406 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatasetResource{}
+var _ resource.ResourceWithModifyPlan = &DatasetResource{}
+
+// NewDatasetResource returns a new instance of DatasetResource.
+func NewDatasetResource() resource.Resource {
+	return &DatasetResource{}
+}
+
+// DatasetResource defines the resource implementation.
+type DatasetResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// DatasetResourceModel describes the resource data model.
+type DatasetResourceModel struct {
+	DatasetToken    types.String `tfsdk:"dataset_token"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	CollectionToken types.String `tfsdk:"collection_token"`
+	DataSourceToken types.String `tfsdk:"data_source_token"`
+	DataSourceId    types.String `tfsdk:"data_source_id"`
+	RawQuery        types.String `tfsdk:"raw_query"`
+	RefreshCron     types.String `tfsdk:"refresh_cron"`
+	RefreshTimeZone types.String `tfsdk:"refresh_time_zone"`
+	State           types.String `tfsdk:"state"`
+}
+
+type RefreshSchedule struct {
+	Cron     string `json:"cron"`
+	TimeZone string `json:"time_zone"`
+}
+
+type Dataset struct {
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	CollectionToken string           `json:"space_token"`
+	DataSourceId    string           `json:"data_source_id"`
+	RawQuery        string           `json:"raw_query"`
+	RefreshSchedule *RefreshSchedule `json:"refresh_schedule"`
+}
+
+type DatasetPayload struct {
+	Dataset Dataset `json:"dataset"`
+}
+
+// datasetResponse is the dataset representation returned by the API.
+type datasetResponse struct {
+	DatasetToken    string           `json:"token"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	CollectionToken string           `json:"space_token"`
+	DataSourceId    string           `json:"data_source_id"`
+	RawQuery        string           `json:"raw_query"`
+	RefreshSchedule *RefreshSchedule `json:"refresh_schedule"`
+	State           string           `json:"state"`
+	CreatedAt       string           `json:"created_at"`
+	UpdatedAt       string           `json:"updated_at"`
+}
+
+// Metadata sets the resource type name.
+func (r *DatasetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset"
+}
+
+// Schema defines the resource schema.
+func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Dataset, a reusable query output stored in a collection",
+
+		Attributes: map[string]schema.Attribute{
+			"dataset_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the dataset",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the dataset",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the dataset",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"collection_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the collection the dataset is stored in",
+				Required:            true,
+			},
+			"data_source_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the data source the dataset queries",
+				Required:            true,
+			},
+			"data_source_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the data source the dataset queries",
+				Computed:            true,
+			},
+			"raw_query": schema.StringAttribute{
+				MarkdownDescription: "SQL query producing the dataset",
+				Required:            true,
+			},
+			"refresh_cron": schema.StringAttribute{
+				MarkdownDescription: "Cron expression of the refresh schedule. The dataset is not refreshed on a schedule when unset",
+				Optional:            true,
+			},
+			"refresh_time_zone": schema.StringAttribute{
+				MarkdownDescription: "Time zone of the refresh schedule",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UTC"),
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the dataset",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *DatasetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *DatasetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create handles the creation of the resource.
+func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := r.payload(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", plan.DataSourceToken.ValueString(), err))
+		return
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/datasets", r.modeHost, r.workspaceId)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dataset, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create dataset, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData datasetResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.DatasetToken = types.StringValue(responseData.DatasetToken)
+	plan.DataSourceId = types.StringValue(payload.Dataset.DataSourceId)
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *DatasetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DatasetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/datasets/%s", r.modeHost, r.workspaceId, state.DatasetToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataset, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData datasetResponse
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+		if responseData.State == "soft_deleted" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		if responseData.DataSourceId != state.DataSourceId.ValueString() {
+			dataSourceToken, err := LookupDataSourceToken(ctx, r.client, r.modeHost, r.workspaceId, responseData.DataSourceId)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", responseData.DataSourceId, err))
+				return
+			}
+			state.DataSourceToken = types.StringValue(dataSourceToken)
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.Description = types.StringValue(responseData.Description)
+		state.CollectionToken = types.StringValue(responseData.CollectionToken)
+		state.DataSourceId = types.StringValue(responseData.DataSourceId)
+		state.RawQuery = types.StringValue(responseData.RawQuery)
+		state.State = types.StringValue(responseData.State)
+		if responseData.RefreshSchedule != nil {
+			state.RefreshCron = types.StringValue(responseData.RefreshSchedule.Cron)
+			state.RefreshTimeZone = types.StringValue(responseData.RefreshSchedule.TimeZone)
+		} else {
+			state.RefreshCron = types.StringNull()
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource.
+func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan DatasetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := r.payload(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", plan.DataSourceToken.ValueString(), err))
+		return
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/datasets/%s", r.modeHost, r.workspaceId, plan.DatasetToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update dataset, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update dataset, got error: %s", url))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData datasetResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.DataSourceId = types.StringValue(payload.Dataset.DataSourceId)
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource.
+func (r *DatasetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state DatasetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/datasets/%s", r.modeHost, r.workspaceId, state.DatasetToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataset, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataset, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Verify deletion of the resource
+	deletionErr := CheckDeletion(url, r.client)
+	if deletionErr != nil {
+		resp.Diagnostics.AddError("Dataset Deletion Error", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *DatasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dataset_token"), req.ID)...)
+}
+
+// payload builds the create and update request body, resolving the data source token to its ID.
+func (r *DatasetResource) payload(ctx context.Context, plan DatasetResourceModel) (DatasetPayload, error) {
+	dataSourceId, err := LookupDataSourceId(ctx, r.client, r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString())
+	if err != nil {
+		return DatasetPayload{}, err
+	}
+
+	payload := DatasetPayload{
+		Dataset: Dataset{
+			Name:            plan.Name.ValueString(),
+			Description:     plan.Description.ValueString(),
+			CollectionToken: plan.CollectionToken.ValueString(),
+			DataSourceId:    dataSourceId,
+			RawQuery:        plan.RawQuery.ValueString(),
+		},
+	}
+	if !plan.RefreshCron.IsNull() {
+		payload.Dataset.RefreshSchedule = &RefreshSchedule{
+			Cron:     plan.RefreshCron.ValueString(),
+			TimeZone: plan.RefreshTimeZone.ValueString(),
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DefinitionResource{}
+var _ resource.ResourceWithModifyPlan = &DefinitionResource{}
+
+// NewDefinitionResource returns a new instance of DefinitionResource.
+func NewDefinitionResource() resource.Resource {
+	return &DefinitionResource{}
+}
+
+// DefinitionResource defines the resource implementation.
+type DefinitionResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// DefinitionResourceModel describes the resource data model.
+type DefinitionResourceModel struct {
+	DefinitionToken types.String `tfsdk:"definition_token"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	DataSourceToken types.String `tfsdk:"data_source_token"`
+	DataSourceId    types.String `tfsdk:"data_source_id"`
+	Source          types.String `tfsdk:"source"`
+	State           types.String `tfsdk:"state"`
+}
+
+type Definition struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	DataSourceId string `json:"data_source_id"`
+	Source       string `json:"source"`
+}
+
+type DefinitionPayload struct {
+	Definition Definition `json:"definition"`
+}
+
+// definitionResponse is the definition representation returned by the API.
+type definitionResponse struct {
+	DefinitionToken string `json:"token"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DataSourceId    string `json:"data_source_id"`
+	Source          string `json:"source"`
+	State           string `json:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *DefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_definition"
+}
+
+// Schema defines the resource schema.
+func (r *DefinitionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reusable SQL definition. Access follows the permissions of its data source, see `modeanalytics_data_source_permission`.",
+
+		Attributes: map[string]schema.Attribute{
+			"definition_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the definition",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the definition",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the definition",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"data_source_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the data source the definition queries",
+				Required:            true,
+			},
+			"data_source_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the data source the definition queries",
+				Computed:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "SQL source of the definition",
+				Required:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the definition",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *DefinitionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *DefinitionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create handles the creation of the resource.
+func (r *DefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan DefinitionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataSourceId, err := LookupDataSourceId(ctx, r.client, r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", plan.DataSourceToken.ValueString(), err))
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/definitions", r.modeHost, r.workspaceId)
+
+	payload := DefinitionPayload{
+		Definition: Definition{
+			Name:         plan.Name.ValueString(),
+			Description:  plan.Description.ValueString(),
+			DataSourceId: dataSourceId,
+			Source:       plan.Source.ValueString(),
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create definition, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create definition, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData definitionResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.DefinitionToken = types.StringValue(responseData.DefinitionToken)
+	plan.DataSourceId = types.StringValue(dataSourceId)
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *DefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/definitions/%s", r.modeHost, r.workspaceId, state.DefinitionToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read definition, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read definition, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData definitionResponse
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+		if responseData.State == "soft_deleted" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		// Edits made in the UI can move the definition to another data source, so the
+		// token is looked up again whenever the ID no longer matches the state.
+		dataSourceId := responseData.DataSourceId
+		if dataSourceId != state.DataSourceId.ValueString() {
+			dataSourceToken, err := LookupDataSourceToken(ctx, r.client, r.modeHost, r.workspaceId, dataSourceId)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", dataSourceId, err))
+				return
+			}
+			state.DataSourceToken = types.StringValue(dataSourceToken)
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.Description = types.StringValue(responseData.Description)
+		state.DataSourceId = types.StringValue(dataSourceId)
+		state.Source = types.StringValue(responseData.Source)
+		state.State = types.StringValue(responseData.State)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource.
+func (r *DefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan DefinitionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataSourceId, err := LookupDataSourceId(ctx, r.client, r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", plan.DataSourceToken.ValueString(), err))
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/definitions/%s", r.modeHost, r.workspaceId, plan.DefinitionToken.ValueString())
+	payload := DefinitionPayload{
+		Definition: Definition{
+			Name:         plan.Name.ValueString(),
+			Description:  plan.Description.ValueString(),
+			DataSourceId: dataSourceId,
+			Source:       plan.Source.ValueString(),
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update definition, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update definition, got error: %s", url))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData definitionResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.DataSourceId = types.StringValue(dataSourceId)
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource.
+func (r *DefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state DefinitionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/definitions/%s", r.modeHost, r.workspaceId, state.DefinitionToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete definition, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete definition, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Verify deletion of the resource
+	deletionErr := CheckDeletion(url, r.client)
+	if deletionErr != nil {
+		resp.Diagnostics.AddError("Definition Deletion Error", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *DefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("definition_token"), req.ID)...)
+}
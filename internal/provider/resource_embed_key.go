@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EmbedKeyResource{}
+var _ resource.ResourceWithModifyPlan = &EmbedKeyResource{}
+
+// NewEmbedKeyResource returns a new instance of EmbedKeyResource.
+func NewEmbedKeyResource() resource.Resource {
+	return &EmbedKeyResource{}
+}
+
+// EmbedKeyResource defines the resource implementation.
+type EmbedKeyResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// EmbedKeyResourceModel describes the resource data model.
+type EmbedKeyResourceModel struct {
+	EmbedKeyToken   types.String `tfsdk:"embed_key_token"`
+	Name            types.String `tfsdk:"name"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	AccessKey       types.String `tfsdk:"access_key"`
+	AccessSecret    types.String `tfsdk:"access_secret"`
+	State           types.String `tfsdk:"state"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+}
+
+type EmbedKey struct {
+	Name string `json:"name"`
+}
+
+type EmbedKeyPayload struct {
+	EmbedKey EmbedKey `json:"embed_key"`
+}
+
+// Metadata sets the resource type name.
+func (r *EmbedKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_embed_key"
+}
+
+// Schema defines the resource schema.
+func (r *EmbedKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Access key for signed white-label embeds. The secret is only returned when the key is created. " +
+			"Changing `rotation_trigger` revokes the key and creates a new one; use `create_before_destroy` to keep embeds working during rotation.",
+
+		Attributes: map[string]schema.Attribute{
+			"embed_key_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the embed key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the embed key",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value that rotates the key when changed",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key": schema.StringAttribute{
+				MarkdownDescription: "Public access key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_secret": schema.StringAttribute{
+				MarkdownDescription: "Access secret used to sign embed URLs. Only known for keys created by Terraform",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the embed key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the key was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *EmbedKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *EmbedKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create handles the creation of the resource.
+func (r *EmbedKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan EmbedKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/embed_keys", r.modeHost, r.workspaceId)
+
+	payload := EmbedKeyPayload{
+		EmbedKey: EmbedKey{
+			Name: plan.Name.ValueString(),
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create embed key, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create embed key, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData struct {
+		EmbedKeyToken string `json:"token"`
+		AccessKey     string `json:"access_key"`
+		AccessSecret  string `json:"access_secret"`
+		State         string `json:"state"`
+		CreatedAt     string `json:"created_at"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.EmbedKeyToken = types.StringValue(responseData.EmbedKeyToken)
+	plan.AccessKey = types.StringValue(responseData.AccessKey)
+	plan.AccessSecret = types.StringValue(responseData.AccessSecret)
+	plan.State = types.StringValue(responseData.State)
+	plan.CreatedAt = types.StringValue(responseData.CreatedAt)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource. The secret is never returned again, so it is kept from the state.
+func (r *EmbedKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EmbedKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/embed_keys/%s", r.modeHost, r.workspaceId, state.EmbedKeyToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read embed key, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read embed key, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData struct {
+			Name      string `json:"name"`
+			AccessKey string `json:"access_key"`
+			State     string `json:"state"`
+			CreatedAt string `json:"created_at"`
+		}
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+		// A key revoked in the UI has to be recreated
+		if responseData.State == "revoked" || responseData.State == "soft_deleted" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.AccessKey = types.StringValue(responseData.AccessKey)
+		state.State = types.StringValue(responseData.State)
+		state.CreatedAt = types.StringValue(responseData.CreatedAt)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource. Every argument forces a new key, so there is nothing to send.
+func (r *EmbedKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan EmbedKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete revokes the embed key.
+func (r *EmbedKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state EmbedKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/embed_keys/%s", r.modeHost, r.workspaceId, state.EmbedKeyToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke embed key, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || (httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke embed key, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *EmbedKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("embed_key_token"), req.ID)...)
+}
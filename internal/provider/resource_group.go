@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,6 +18,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
 
 // NewGroupResource returns a new instance of GroupResource.
 func NewGroupResource() resource.Resource {
@@ -28,13 +30,17 @@ type GroupResource struct {
 	client      *http.Client
 	modeHost    string
 	workspaceId string
+	readOnly    bool
+	scim        *ScimClient
 }
 
 // GroupResourceModel describes the resource data model.
 type GroupResourceModel struct {
-	GroupToken types.String `tfsdk:"group_token"`
-	Name       types.String `tfsdk:"name"`
-	State      types.String `tfsdk:"state"`
+	GroupToken         types.String `tfsdk:"group_token"`
+	Name               types.String `tfsdk:"name"`
+	State              types.String `tfsdk:"state"`
+	RestoreSoftDeleted types.Bool   `tfsdk:"restore_soft_deleted"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type UserGroup struct {
@@ -61,11 +67,24 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				MarkdownDescription: "Name of the group. Renaming to the name of a soft-deleted group is rejected while planning",
+				Required:            true,
 			},
 			"state": schema.StringAttribute{
 				Computed: true,
 			},
+			"restore_soft_deleted": schema.BoolAttribute{
+				MarkdownDescription: "Restore the group with its existing token if it was deleted outside of Terraform, instead of creating a new one",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the group from being destroyed or replaced until this is set to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -80,6 +99,10 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	})
 
 	if !ok {
@@ -93,10 +116,49 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = config.Client
 	r.modeHost = config.ModeHost
 	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.scim = config.Scim
+}
+
+// ModifyPlan plans the restore of a soft-deleted group and rejects renames to the name of another soft-deleted group.
+// SCIM groups are deleted outright, so there is nothing to restore or collide with.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if r.scim != nil {
+		return
+	}
+	PlanSoftDeletedRestore(ctx, req, resp)
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan, state GroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Name.IsUnknown() || plan.Name.ValueString() == state.Name.ValueString() {
+		return
+	}
+
+	// A soft-deleted group holding the new name would block the deletion of this group later on.
+	// It is not managed here, so it is left to the user to rename or purge it.
+	colliding, err := r.softDeletedGroupsNamed(ctx, plan.Name.ValueString(), state.GroupToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups, got error: %s", err))
+		return
+	}
+	for _, token := range colliding {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Name Held By Soft-Deleted Group",
+			fmt.Sprintf("The soft-deleted group %s is named %q. Rename or permanently delete it before renaming this group.", token, plan.Name.ValueString()))
+	}
 }
 
 // Create handles the creation of the resource.
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan GroupResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -104,6 +166,19 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	if r.scim != nil {
+		group, err := r.scim.CreateGroup(ctx, plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SCIM group, got error: %s", err))
+			return
+		}
+		plan.GroupToken = types.StringValue(group.Id)
+		plan.State = types.StringValue("active")
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/groups", r.modeHost, r.workspaceId)
 
 	payload := Payload{
@@ -152,6 +227,23 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	if r.scim != nil {
+		group, err := r.scim.GetGroup(ctx, state.GroupToken.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SCIM group, got error: %s", err))
+			return
+		}
+		if group == nil {
+			AddScimStateError(&resp.Diagnostics, "group", state.GroupToken.ValueString())
+			return
+		}
+		state.Name = types.StringValue(group.DisplayName)
+		state.State = types.StringValue("active")
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString())
 	httpReq, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -177,7 +269,7 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
 			return
 		}
-		if responseData.State == "soft_deleted" {
+		if responseData.State == "soft_deleted" && !state.RestoreSoftDeleted.ValueBool() {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -194,14 +286,40 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update handles updating the resource.
 func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan GroupResourceModel
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state GroupResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// SCIM has no soft deletion, so a rename is the only change to send
+	if r.scim != nil {
+		err := r.scim.PatchGroup(ctx, plan.GroupToken.ValueString(), ScimPatchOperation{Op: "replace", Path: "displayName", Value: plan.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SCIM group, got error: %s", err))
+			return
+		}
+		plan.State = state.State
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, plan.GroupToken.ValueString())
+
+	if state.State.ValueString() == "soft_deleted" && plan.RestoreSoftDeleted.ValueBool() {
+		if err := RestoreSoftDeleted(ctx, r.client, url); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore group, got error: %s", err))
+			return
+		}
+	}
+
 	payload := Payload{
 		UserGroup: UserGroup{
 			Name: plan.Name.ValueString(),
@@ -239,6 +357,10 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete handles deleting the resource.
 func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var state GroupResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -246,6 +368,35 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if CheckDeletionProtection(state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	if r.scim != nil {
+		if err := r.scim.DeleteGroup(ctx, state.GroupToken.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete SCIM group, got error: %s", err))
+			return
+		}
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The API cannot delete a group whose name matches an already deleted group,
+	// so the group is renamed to a unique tombstone name first.
+	colliding, err := r.softDeletedGroupsNamed(ctx, state.Name.ValueString(), state.GroupToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups, got error: %s", err))
+		return
+	}
+	if len(colliding) > 0 {
+		err = r.renameGroup(ctx, state.GroupToken.ValueString(), tombstoneGroupName(state.Name.ValueString(), state.GroupToken.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename group before deletion, got error: %s", err))
+			return
+		}
+	}
+
 	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString())
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -263,7 +414,7 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	// Verify deletion of the resource
 	deletionErr := CheckDeletion(url, r.client)
 	if deletionErr != nil {
-		resp.Diagnostics.AddError("Group Deletion Error. If the name of the group matches one that was already deleted, its name needs to be changed before it can be deleted (API limitation)", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
+		resp.Diagnostics.AddError("Group Deletion Error", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
 		return
 	}
 
@@ -271,6 +422,62 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	resp.State.RemoveResource(ctx)
 }
 
+// softDeletedGroupsNamed returns the tokens of soft-deleted groups other than exceptToken with the given name.
+func (r *GroupResource) softDeletedGroupsNamed(ctx context.Context, name string, exceptToken string) ([]string, error) {
+	var responseData struct {
+		Embedded struct {
+			Groups []struct {
+				GroupToken string `json:"token"`
+				Name       string `json:"name"`
+				State      string `json:"state"`
+			} `json:"groups"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/groups", r.modeHost, r.workspaceId)
+	if err := HttpGetJSON(ctx, r.client, url, &responseData); err != nil {
+		return nil, err
+	}
+
+	tokens := []string{}
+	for _, group := range responseData.Embedded.Groups {
+		if group.State == "soft_deleted" && group.Name == name && group.GroupToken != exceptToken {
+			tokens = append(tokens, group.GroupToken)
+		}
+	}
+	return tokens, nil
+}
+
+// renameGroup changes the name of a group.
+func (r *GroupResource) renameGroup(ctx context.Context, groupToken string, name string) error {
+	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, groupToken)
+	payload := Payload{
+		UserGroup: UserGroup{
+			Name: name,
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// tombstoneGroupName returns a name for a group that is about to be deleted. The token keeps it unique.
+func tombstoneGroupName(name string, groupToken string) string {
+	return fmt.Sprintf("%s (deleted %s)", name, groupToken)
+}
+
 func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_token"), req.ID)...)
 }
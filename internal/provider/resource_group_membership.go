@@ -6,17 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &GroupMembershipResource{}
 
 // NewGroupMembershipResource returns a new instance of GroupMembershipResource.
 func NewGroupMembershipResource() resource.Resource {
@@ -28,13 +33,18 @@ type GroupMembershipResource struct {
 	client      *http.Client
 	modeHost    string
 	workspaceId string
+	readOnly    bool
+	cache       *ReadCache
+	scim        *ScimClient
 }
 
 // GroupMembershipResourceModel describes the resource data model.
 type GroupMembershipResourceModel struct {
-	GroupToken      types.String `tfsdk:"group_token"`
-	MemberToken     types.String `tfsdk:"member_token"`
-	MembershipToken types.String `tfsdk:"membership_token"`
+	GroupToken         types.String `tfsdk:"group_token"`
+	MemberToken        types.String `tfsdk:"member_token"`
+	MemberUsername     types.String `tfsdk:"member_username"`
+	MembershipToken    types.String `tfsdk:"membership_token"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type Membership struct {
@@ -61,13 +71,34 @@ func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.Schem
 				},
 			},
 			"member_token": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("member_username")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_username": schema.StringAttribute{
+				MarkdownDescription: "Username of the member, resolved to `member_token` during planning",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"membership_token": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the group membership from being destroyed or replaced until this is set to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
 	}
@@ -83,6 +114,10 @@ func (r *GroupMembershipResource) Configure(ctx context.Context, req resource.Co
 		Client      *http.Client
 		ModeHost    string
 		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
 	})
 
 	if !ok {
@@ -96,10 +131,38 @@ func (r *GroupMembershipResource) Configure(ctx context.Context, req resource.Co
 	r.client = config.Client
 	r.modeHost = config.ModeHost
 	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.cache = config.Cache
+	r.scim = config.Scim
+}
+
+// ModifyPlan resolves member_username to the member token and checks that the group and member exist.
+// Under the SCIM backend the username is resolved to the SCIM user id instead.
+func (r *GroupMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if r.scim != nil {
+		ResolveUsernamePlan(ctx, req, resp, path.Root("member_username"), path.Root("member_token"), func(username string) (string, error) {
+			return r.scim.FindUserId(ctx, username)
+		})
+		return
+	}
+
+	ResolveMemberUsernamePlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp, path.Root("member_username"), path.Root("member_token"))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ValidateReferencePlan(ctx, r.client, req, resp, path.Root("group_token"), "group", r.modeHost+"/api/"+r.workspaceId+"/groups/%s")
+	ValidateMemberPlan(ctx, r.cache, r.client, r.modeHost, r.workspaceId, req, resp, path.Root("member_token"))
 }
 
 // Create handles the creation of the resource.
 func (r *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan GroupMembershipResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -107,6 +170,21 @@ func (r *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// SCIM memberships have no token of their own, so the group and user ids identify them
+	if r.scim != nil {
+		err := r.scim.PatchGroup(ctx, plan.GroupToken.ValueString(), AddMemberOperation(plan.MemberToken.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add member to SCIM group, got error: %s", err))
+			return
+		}
+		plan.MembershipToken = types.StringValue(plan.GroupToken.ValueString() + "/" + plan.MemberToken.ValueString())
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	defer r.cache.Invalidate(r.listURL(plan.GroupToken.ValueString()))
+
 	url := fmt.Sprintf("%s/api/%s/groups/%s/memberships", r.modeHost, r.workspaceId, plan.GroupToken.ValueString())
 
 	payload := GroupMembershipPayload{
@@ -153,51 +231,74 @@ func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	url := fmt.Sprintf("%s/api/%s/groups/%s/memberships/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString(), state.MembershipToken.ValueString())
-	httpReq, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group membership, got error: %s", err))
+	if r.scim != nil {
+		// Memberships created through SCIM are identified by "<group_token>/<member_token>"
+		if !strings.Contains(state.MembershipToken.ValueString(), "/") {
+			AddScimStateError(&resp.Diagnostics, "group membership", state.MembershipToken.ValueString())
+			return
+		}
+		group, err := r.scim.GetGroup(ctx, state.GroupToken.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SCIM group, got error: %s", err))
+			return
+		}
+		if group == nil {
+			AddScimStateError(&resp.Diagnostics, "group", state.GroupToken.ValueString())
+			return
+		}
+		if !group.HasMember(state.MemberToken.ValueString()) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
 
-	httpResp, err := HttpRetry(r.client, httpReq)
+	// The list of the parent is shared by all group memberships read during this run
+	children, err := r.cache.Children(ctx, r.client, r.listURL(state.GroupToken.ValueString()), "group_memberships")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group membership, got error: %s", err))
 		return
 	}
-	defer httpResp.Body.Close()
 
-	var responseData struct {
-		MembershipToken string `json:"token"`
-		MemberToken     string `json:"member_token"`
-	}
-
-	if httpResp.StatusCode == http.StatusOK {
-		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
-			return
-		}
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	} else if httpResp.StatusCode == http.StatusNotFound {
+	_, ok := children[state.MembershipToken.ValueString()]
+	if !ok {
 		resp.State.RemoveResource(ctx)
-	} else {
-		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+		return
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// listURL returns the URL listing the memberships of a parent, which is also the key of its cached list.
+func (r *GroupMembershipResource) listURL(parentToken string) string {
+	return fmt.Sprintf("%s/api/%s/groups/%s/memberships", r.modeHost, r.workspaceId, parentToken)
 }
 
 // Update handles updating the resource.
 func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var plan GroupMembershipResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Only deletion_protection can change in place, so there is nothing to send
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 // Delete handles deleting the resource.
 func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
 	var state GroupMembershipResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -205,6 +306,23 @@ func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	defer r.cache.Invalidate(r.listURL(state.GroupToken.ValueString()))
+
+	if CheckDeletionProtection(state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	if r.scim != nil {
+		err := r.scim.PatchGroup(ctx, state.GroupToken.ValueString(), RemoveMemberOperation(state.MemberToken.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove member from SCIM group, got error: %s", err))
+			return
+		}
+
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	url := fmt.Sprintf("%s/api/%s/groups/%s/memberships/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString(), state.MembershipToken.ValueString())
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -230,6 +348,16 @@ func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 	resp.State.RemoveResource(ctx)
 }
 
+// ImportState takes the membership token, or "<group_token>/<member_token>" when groups are managed through SCIM.
 func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.scim != nil {
+		groupToken, memberToken, ok := strings.Cut(req.ID, "/")
+		if !ok || groupToken == "" || memberToken == "" {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected an import ID of the form <group_token>/<member_token>, got: %s", req.ID))
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_token"), groupToken)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_token"), memberToken)...)
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("membership_token"), req.ID)...)
 }
@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupSyncResource{}
+var _ resource.ResourceWithModifyPlan = &GroupSyncResource{}
+
+// NewGroupSyncResource returns a new instance of GroupSyncResource.
+func NewGroupSyncResource() resource.Resource {
+	return &GroupSyncResource{}
+}
+
+// GroupSyncResource defines the resource implementation.
+type GroupSyncResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+	cache       *ReadCache
+	scim        *ScimClient
+}
+
+// GroupSyncResourceModel describes the resource data model.
+type GroupSyncResourceModel struct {
+	GroupName        types.String `tfsdk:"group_name"`
+	Emails           types.Set    `tfsdk:"emails"`
+	GroupToken       types.String `tfsdk:"group_token"`
+	GroupCreated     types.Bool   `tfsdk:"group_created"`
+	MemberTokens     types.Set    `tfsdk:"member_tokens"`
+	UnresolvedEmails types.Set    `tfsdk:"unresolved_emails"`
+}
+
+// Metadata sets the resource type name.
+func (r *GroupSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_sync"
+}
+
+// Schema defines the resource schema.
+func (r *GroupSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Keeps the members of a group in sync with a list of email addresses. The group is created if no group " +
+			"has the name yet. Members not in the list are removed from the group, and emails without an active workspace member are reported as warnings. " +
+			"Do not combine with `modeanalytics_group_membership` resources for the same group. Not available when `user_management_backend` is `scim`.",
+
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"emails": schema.SetAttribute{
+				MarkdownDescription: "Email addresses of the members the group should have",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"group_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_created": schema.BoolAttribute{
+				MarkdownDescription: "Whether the group was created by this resource, in which case it is deleted on destroy",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_tokens": schema.SetAttribute{
+				MarkdownDescription: "Tokens of the members of the group",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"unresolved_emails": schema.SetAttribute{
+				MarkdownDescription: "Emails that do not belong to an active workspace member",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *GroupSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+	r.cache = config.Cache
+	r.scim = config.Scim
+}
+
+// ModifyPlan resolves the emails into the planned member tokens, so that membership changes show up in the plan.
+func (r *GroupSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if req.Plan.Raw.IsNull() || CheckScimGroups(r.scim, path.Root("group_name"), &resp.Diagnostics) {
+		return
+	}
+
+	var plan GroupSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Emails.IsUnknown() || r.client == nil {
+		return
+	}
+
+	memberTokens, unresolved := r.resolveEmails(ctx, plan.Emails, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, email := range unresolved {
+		resp.Diagnostics.AddAttributeWarning(path.Root("emails"), "Unresolved Email", fmt.Sprintf("No active workspace member has the email %q, so it is skipped", email))
+	}
+
+	plan.MemberTokens, _ = types.SetValueFrom(ctx, types.StringType, memberTokens)
+	plan.UnresolvedEmails, _ = types.SetValueFrom(ctx, types.StringType, unresolved)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create handles the creation of the resource.
+func (r *GroupSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan GroupSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupToken, err := r.findGroup(ctx, plan.GroupName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list groups, got error: %s", err))
+		return
+	}
+
+	plan.GroupCreated = types.BoolValue(groupToken == "")
+	if groupToken == "" {
+		groupToken, err = r.createGroup(ctx, plan.GroupName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create group, got error: %s", err))
+			return
+		}
+	}
+	plan.GroupToken = types.StringValue(groupToken)
+
+	r.sync(ctx, &plan, &resp.Diagnostics)
+
+	// The state is saved even on failure, so that a created group is tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *GroupSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+		return
+	}
+
+	var responseData struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+	if responseData.State == "soft_deleted" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.GroupName = types.StringValue(responseData.Name)
+
+	memberships, err := r.cache.Children(ctx, r.client, r.listURL(state.GroupToken.ValueString()), "group_memberships")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group memberships, got error: %s", err))
+		return
+	}
+
+	memberTokens := []string{}
+	for _, membership := range memberships {
+		memberTokens = append(memberTokens, membership.MemberToken)
+	}
+	sort.Strings(memberTokens)
+	state.MemberTokens, _ = types.SetValueFrom(ctx, types.StringType, memberTokens)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update handles updating the resource.
+func (r *GroupSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan GroupSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, &plan, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the group if it was created by the resource. An adopted group is left as it is.
+func (r *GroupSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state GroupSyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.GroupCreated.ValueBool() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	defer r.cache.Invalidate(r.listURL(state.GroupToken.ValueString()))
+
+	url := fmt.Sprintf("%s/api/%s/groups/%s", r.modeHost, r.workspaceId, state.GroupToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || (httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Verify deletion of the resource
+	deletionErr := CheckDeletion(url, r.client)
+	if deletionErr != nil {
+		resp.Diagnostics.AddError("Group Deletion Error", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+// sync adds the missing members to the group and removes the extra ones.
+// plan.MemberTokens is set to the members of the group afterwards.
+func (r *GroupSyncResource) sync(ctx context.Context, plan *GroupSyncResourceModel, diags *diag.Diagnostics) {
+	desired, unresolved := r.resolveEmails(ctx, plan.Emails, diags)
+	if diags.HasError() {
+		return
+	}
+	plan.UnresolvedEmails, _ = types.SetValueFrom(ctx, types.StringType, unresolved)
+
+	listURL := r.listURL(plan.GroupToken.ValueString())
+	defer r.cache.Invalidate(listURL)
+
+	memberships, err := r.cache.Children(ctx, r.client, listURL, "group_memberships")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read group memberships, got error: %s", err))
+		return
+	}
+
+	current := map[string]string{}
+	for _, membership := range memberships {
+		current[membership.MemberToken] = membership.Token
+	}
+
+	members := map[string]bool{}
+	for memberToken := range current {
+		members[memberToken] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, memberToken := range desired {
+		wanted[memberToken] = true
+		if _, ok := current[memberToken]; ok {
+			continue
+		}
+
+		payload := GroupMembershipPayload{
+			Membership: Membership{
+				MemberToken: memberToken,
+			},
+		}
+		jsonBody, _ := json.Marshal(payload)
+		if err := r.send(ctx, http.MethodPost, listURL, jsonBody); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to add member %s to group, got error: %s", memberToken, err))
+			continue
+		}
+		members[memberToken] = true
+	}
+
+	for memberToken, membershipToken := range current {
+		if wanted[memberToken] {
+			continue
+		}
+		if err := r.send(ctx, http.MethodDelete, listURL+"/"+membershipToken, nil); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove member %s from group, got error: %s", memberToken, err))
+			continue
+		}
+		delete(members, memberToken)
+	}
+
+	memberTokens := []string{}
+	for memberToken := range members {
+		memberTokens = append(memberTokens, memberToken)
+	}
+	sort.Strings(memberTokens)
+	plan.MemberTokens, _ = types.SetValueFrom(ctx, types.StringType, memberTokens)
+}
+
+// resolveEmails returns the member tokens of the active members with the given emails, and the emails
+// that could not be resolved.
+func (r *GroupSyncResource) resolveEmails(ctx context.Context, emails types.Set, diags *diag.Diagnostics) ([]string, []string) {
+	var wanted []string
+	diags.Append(emails.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return nil, nil
+	}
+
+	members, err := ListWorkspaceMembers(ctx, r.client, r.modeHost, r.workspaceId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list memberships: %s", err))
+		return nil, nil
+	}
+
+	// Account emails are cached, so applying does not read the accounts again after planning
+	matched, err := MatchMemberEmails(ctx, r.cache, r.client, r.modeHost, members, wanted, true)
+	if err != nil {
+		diags.AddError("Client Error", err.Error())
+		return nil, nil
+	}
+
+	memberTokens := []string{}
+	unresolved := []string{}
+	for _, email := range wanted {
+		if member, ok := matched[strings.ToLower(email)]; ok {
+			memberTokens = append(memberTokens, member.MemberToken)
+		} else {
+			unresolved = append(unresolved, email)
+		}
+	}
+	sort.Strings(memberTokens)
+	sort.Strings(unresolved)
+	return memberTokens, unresolved
+}
+
+// findGroup returns the token of the group with the given name that is not soft-deleted, or "" if there is none.
+func (r *GroupSyncResource) findGroup(ctx context.Context, name string) (string, error) {
+	var responseData struct {
+		Embedded struct {
+			Groups []struct {
+				GroupToken string `json:"token"`
+				Name       string `json:"name"`
+				State      string `json:"state"`
+			} `json:"groups"`
+		} `json:"_embedded"`
+	}
+	url := fmt.Sprintf("%s/api/%s/groups", r.modeHost, r.workspaceId)
+	if err := HttpGetJSON(ctx, r.client, url, &responseData); err != nil {
+		return "", err
+	}
+
+	for _, group := range responseData.Embedded.Groups {
+		if group.Name == name && group.State != "soft_deleted" {
+			return group.GroupToken, nil
+		}
+	}
+	return "", nil
+}
+
+// createGroup creates a group and returns its token.
+func (r *GroupSyncResource) createGroup(ctx context.Context, name string) (string, error) {
+	payload := Payload{
+		UserGroup: UserGroup{
+			Name: name,
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/groups", r.modeHost, r.workspaceId)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	var responseData struct {
+		GroupToken string `json:"token"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&responseData); err != nil {
+		return "", fmt.Errorf("error parsing response: %s", err)
+	}
+	return responseData.GroupToken, nil
+}
+
+// send issues a request that is expected to answer with 200.
+func (r *GroupSyncResource) send(ctx context.Context, method string, url string, jsonBody []byte) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// listURL returns the URL listing the memberships of a group, which is also the key of its cached list.
+func (r *GroupSyncResource) listURL(groupToken string) string {
+	return fmt.Sprintf("%s/api/%s/groups/%s/memberships", r.modeHost, r.workspaceId, groupToken)
+}
+
+// ImportState adopts an existing group, which is therefore left in place on destroy.
+func (r *GroupSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_token"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_created"), false)...)
+}
@@ -0,0 +1,679 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReportBundleResource{}
+var _ resource.ResourceWithModifyPlan = &ReportBundleResource{}
+
+// NewReportBundleResource returns a new instance of ReportBundleResource.
+func NewReportBundleResource() resource.Resource {
+	return &ReportBundleResource{}
+}
+
+// ReportBundleResource defines the resource implementation.
+type ReportBundleResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ReportBundleResourceModel describes the resource data model.
+type ReportBundleResourceModel struct {
+	Directory       types.String `tfsdk:"directory"`
+	CollectionToken types.String `tfsdk:"collection_token"`
+	DataSourceToken types.String `tfsdk:"data_source_token"`
+	ReportToken     types.String `tfsdk:"report_token"`
+	Name            types.String `tfsdk:"name"`
+	ContentHash     types.String `tfsdk:"content_hash"`
+	Queries         types.Map    `tfsdk:"queries"`
+}
+
+type ReportBundleQueryModel struct {
+	QueryToken  types.String `tfsdk:"query_token"`
+	Name        types.String `tfsdk:"name"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+var reportBundleQueryAttrTypes = map[string]attr.Type{
+	"query_token":  types.StringType,
+	"name":         types.StringType,
+	"content_hash": types.StringType,
+}
+
+// Report is the body of a report write. A nil parameter value resets the parameter to its default.
+type Report struct {
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	CollectionToken string             `json:"space_token"`
+	Parameters      map[string]*string `json:"parameters,omitempty"`
+}
+
+type ReportPayload struct {
+	Report Report `json:"report"`
+}
+
+type Query struct {
+	Name         string `json:"name"`
+	RawQuery     string `json:"raw_query"`
+	DataSourceId string `json:"data_source_id"`
+}
+
+type QueryPayload struct {
+	Query Query `json:"query"`
+}
+
+// reportBundleMetadataFile is the file of a bundle directory holding the report metadata.
+const reportBundleMetadataFile = "report.yaml"
+
+// reportBundleMetadata is the content of report.yaml. Queries are keyed by SQL file name.
+type reportBundleMetadata struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Queries     map[string]struct {
+		Name string `yaml:"name"`
+	} `yaml:"queries"`
+}
+
+// reportBundle is a bundle directory loaded from disk.
+type reportBundle struct {
+	Name        string
+	Description string
+	Queries     []reportBundleQuery
+	ContentHash string
+}
+
+type reportBundleQuery struct {
+	File        string
+	Name        string
+	RawQuery    string
+	ContentHash string
+}
+
+// Metadata sets the resource type name.
+func (r *ReportBundleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_bundle"
+}
+
+// Schema defines the resource schema.
+func (r *ReportBundleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Report published from a local directory holding a `report.yaml` and one `.sql` file per query. " +
+			"Changes are detected by content hash; queries whose files were deleted are removed from the report. " +
+			"The queries of an imported report are matched to the files by name",
+
+		Attributes: map[string]schema.Attribute{
+			"directory": schema.StringAttribute{
+				MarkdownDescription: "Path of the bundle directory. `report.yaml` sets `name`, `description` and optional query names under `queries.<file>.name`",
+				Required:            true,
+			},
+			"collection_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the collection the report is stored in",
+				Required:            true,
+			},
+			"data_source_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the data source the queries run against",
+				Required:            true,
+			},
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the report, read from `report.yaml`",
+				Computed:            true,
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the bundle content",
+				Computed:            true,
+			},
+			"queries": schema.MapNestedAttribute{
+				MarkdownDescription: "Queries of the report, keyed by SQL file name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"query_token": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"content_hash": schema.StringAttribute{
+							MarkdownDescription: "SHA-256 hash of the SQL file",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ReportBundleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan loads the bundle directory, so that changed files show up in the plan.
+// Queries whose files existed before keep their token.
+func (r *ReportBundleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer CheckWritePlan(ctx, r.readOnly, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ReportBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Directory.IsUnknown() {
+		return
+	}
+
+	bundle, err := loadReportBundle(plan.Directory.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("directory"), "Invalid Report Bundle", err.Error())
+		return
+	}
+
+	existing := map[string]ReportBundleQueryModel{}
+	if !req.State.Raw.IsNull() {
+		var state ReportBundleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Queries.ElementsAs(ctx, &existing, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	existing = bundle.matchQueries(existing)
+
+	queries := map[string]ReportBundleQueryModel{}
+	for _, query := range bundle.Queries {
+		queryToken := types.StringUnknown()
+		if current, ok := existing[query.File]; ok {
+			queryToken = current.QueryToken
+		}
+		queries[query.File] = ReportBundleQueryModel{
+			QueryToken:  queryToken,
+			Name:        types.StringValue(query.Name),
+			ContentHash: types.StringValue(query.ContentHash),
+		}
+	}
+
+	plan.Name = types.StringValue(bundle.Name)
+	plan.ContentHash = types.StringValue(bundle.ContentHash)
+	var diags diag.Diagnostics
+	plan.Queries, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: reportBundleQueryAttrTypes}, queries)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create handles the creation of the resource.
+func (r *ReportBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportBundleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bundle, dataSourceId := r.load(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports", r.modeHost, r.workspaceId)
+	jsonBody, _ := json.Marshal(bundle.reportPayload(plan.CollectionToken.ValueString()))
+	var responseData struct {
+		ReportToken string `json:"token"`
+	}
+	if err := r.send(ctx, http.MethodPost, url, jsonBody, &responseData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create report, got error: %s", err))
+		return
+	}
+	plan.ReportToken = types.StringValue(responseData.ReportToken)
+
+	r.syncQueries(ctx, &plan, bundle, dataSourceId, nil, true, &resp.Diagnostics)
+
+	// The state is saved even on failure, so that the report and the queries created so far are tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource. Queries edited or deleted in Mode show up as drift.
+func (r *ReportBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ReportBundleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, state.ReportToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+		return
+	}
+
+	var responseData struct {
+		Name            string `json:"name"`
+		CollectionToken string `json:"space_token"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+	state.Name = types.StringValue(responseData.Name)
+	state.CollectionToken = types.StringValue(responseData.CollectionToken)
+
+	var queriesData struct {
+		Embedded struct {
+			Queries []struct {
+				QueryToken string `json:"token"`
+				Name       string `json:"name"`
+				RawQuery   string `json:"raw_query"`
+			} `json:"queries"`
+		} `json:"_embedded"`
+	}
+	if err := HttpGetJSON(ctx, r.client, url+"/queries", &queriesData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list queries of report, got error: %s", err))
+		return
+	}
+
+	remote := map[string]reportBundleQuery{}
+	for _, query := range queriesData.Embedded.Queries {
+		remote[query.QueryToken] = reportBundleQuery{Name: query.Name, ContentHash: contentHash(query.RawQuery)}
+	}
+
+	existing := map[string]ReportBundleQueryModel{}
+	resp.Diagnostics.Append(state.Queries.ElementsAs(ctx, &existing, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported report has no queries in the state yet. They are keyed by token until the next plan
+	// matches them to the files of the directory by name.
+	if state.Queries.IsNull() {
+		for queryToken := range remote {
+			existing[queryToken] = ReportBundleQueryModel{QueryToken: types.StringValue(queryToken)}
+		}
+	}
+
+	// Deleted queries are dropped and edited ones take the remote hash, so the next apply restores them
+	queries := map[string]ReportBundleQueryModel{}
+	for file, query := range existing {
+		current, ok := remote[query.QueryToken.ValueString()]
+		if !ok {
+			continue
+		}
+		queries[file] = ReportBundleQueryModel{
+			QueryToken:  query.QueryToken,
+			Name:        types.StringValue(current.Name),
+			ContentHash: types.StringValue(current.ContentHash),
+		}
+	}
+	var diags diag.Diagnostics
+	state.Queries, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: reportBundleQueryAttrTypes}, queries)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update handles updating the resource.
+func (r *ReportBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state ReportBundleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bundle, dataSourceId := r.load(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, plan.ReportToken.ValueString())
+	jsonBody, _ := json.Marshal(bundle.reportPayload(plan.CollectionToken.ValueString()))
+	if err := r.send(ctx, http.MethodPatch, url, jsonBody, nil); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update report, got error: %s", err))
+		return
+	}
+
+	existing := map[string]ReportBundleQueryModel{}
+	resp.Diagnostics.Append(state.Queries.ElementsAs(ctx, &existing, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing = bundle.matchQueries(existing)
+
+	dataSourceChanged := plan.DataSourceToken.ValueString() != state.DataSourceToken.ValueString()
+	r.syncQueries(ctx, &plan, bundle, dataSourceId, existing, dataSourceChanged, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource.
+func (r *ReportBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state ReportBundleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, state.ReportToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || (httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete report, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ReportBundleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("report_token"), req.ID)...)
+}
+
+// load reloads the bundle directory and resolves the data source. The bundle must not have changed since planning.
+func (r *ReportBundleResource) load(ctx context.Context, plan ReportBundleResourceModel, diags *diag.Diagnostics) (*reportBundle, string) {
+	bundle, err := loadReportBundle(plan.Directory.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("directory"), "Invalid Report Bundle", err.Error())
+		return nil, ""
+	}
+	if bundle.ContentHash != plan.ContentHash.ValueString() {
+		diags.AddAttributeError(path.Root("directory"), "Report Bundle Changed",
+			fmt.Sprintf("The content of %s changed after planning. Run the plan again.", plan.Directory.ValueString()))
+		return nil, ""
+	}
+
+	dataSourceId, err := LookupDataSourceId(ctx, r.client, r.modeHost, r.workspaceId, plan.DataSourceToken.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read data source %s, got error: %s", plan.DataSourceToken.ValueString(), err))
+		return nil, ""
+	}
+	return bundle, dataSourceId
+}
+
+// syncQueries creates the queries of new files, updates the queries of changed files and deletes the
+// queries of deleted files. plan.Queries is set to the queries of the report afterwards.
+func (r *ReportBundleResource) syncQueries(ctx context.Context, plan *ReportBundleResourceModel, bundle *reportBundle, dataSourceId string, existing map[string]ReportBundleQueryModel, updateAll bool, diags *diag.Diagnostics) {
+	listURL := fmt.Sprintf("%s/api/%s/reports/%s/queries", r.modeHost, r.workspaceId, plan.ReportToken.ValueString())
+
+	applied := map[string]ReportBundleQueryModel{}
+	wanted := map[string]bool{}
+	for _, query := range bundle.Queries {
+		wanted[query.File] = true
+		jsonBody, _ := json.Marshal(QueryPayload{
+			Query: Query{
+				Name:         query.Name,
+				RawQuery:     query.RawQuery,
+				DataSourceId: dataSourceId,
+			},
+		})
+
+		current, ok := existing[query.File]
+		if !ok {
+			var responseData struct {
+				QueryToken string `json:"token"`
+			}
+			if err := r.send(ctx, http.MethodPost, listURL, jsonBody, &responseData); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to create query for %s, got error: %s", query.File, err))
+				continue
+			}
+			current.QueryToken = types.StringValue(responseData.QueryToken)
+		} else if updateAll || current.ContentHash.ValueString() != query.ContentHash || current.Name.ValueString() != query.Name {
+			if err := r.send(ctx, http.MethodPatch, listURL+"/"+current.QueryToken.ValueString(), jsonBody, nil); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to update query for %s, got error: %s", query.File, err))
+				applied[query.File] = current
+				continue
+			}
+		}
+
+		applied[query.File] = ReportBundleQueryModel{
+			QueryToken:  current.QueryToken,
+			Name:        types.StringValue(query.Name),
+			ContentHash: types.StringValue(query.ContentHash),
+		}
+	}
+
+	for file, query := range existing {
+		if wanted[file] {
+			continue
+		}
+		if err := r.send(ctx, http.MethodDelete, listURL+"/"+query.QueryToken.ValueString(), nil, nil); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete query for %s, got error: %s", file, err))
+			applied[file] = query
+		}
+	}
+
+	queries, queriesDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: reportBundleQueryAttrTypes}, applied)
+	diags.Append(queriesDiags...)
+	plan.Queries = queries
+}
+
+// send issues a request that is expected to answer with 200 and decodes the response into target, if any.
+func (r *ReportBundleResource) send(ctx context.Context, method string, url string, jsonBody []byte, target interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	if target == nil {
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(target); err != nil {
+		return fmt.Errorf("error parsing response: %s", err)
+	}
+	return nil
+}
+
+// reportPayload returns the payload creating or updating the report of the bundle.
+func (b *reportBundle) reportPayload(collectionToken string) ReportPayload {
+	return ReportPayload{
+		Report: Report{
+			Name:            b.Name,
+			Description:     b.Description,
+			CollectionToken: collectionToken,
+		},
+	}
+}
+
+// matchQueries keys the queries of the state by the file they belong to. A query whose file is not
+// in the state, as after an import, takes the query of the same name. Queries left over keep their key.
+func (b *reportBundle) matchQueries(existing map[string]ReportBundleQueryModel) map[string]ReportBundleQueryModel {
+	matched := map[string]ReportBundleQueryModel{}
+	for _, query := range b.Queries {
+		if current, ok := existing[query.File]; ok {
+			matched[query.File] = current
+		}
+	}
+
+	unmatched := make([]string, 0, len(existing))
+	for key := range existing {
+		if _, ok := matched[key]; !ok {
+			unmatched = append(unmatched, key)
+		}
+	}
+	sort.Strings(unmatched)
+
+	for _, query := range b.Queries {
+		if _, ok := matched[query.File]; ok {
+			continue
+		}
+		for i, key := range unmatched {
+			if existing[key].Name.ValueString() == query.Name {
+				matched[query.File] = existing[key]
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for _, key := range unmatched {
+		matched[key] = existing[key]
+	}
+	return matched
+}
+
+// loadReportBundle reads report.yaml and the .sql files of a bundle directory. Subdirectories are ignored.
+func loadReportBundle(directory string) (*reportBundle, error) {
+	metadataContent, err := os.ReadFile(filepath.Join(directory, reportBundleMetadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", reportBundleMetadataFile, err)
+	}
+
+	var metadata reportBundleMetadata
+	if err := yaml.Unmarshal(metadataContent, &metadata); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", reportBundleMetadataFile, err)
+	}
+	if metadata.Name == "" {
+		return nil, fmt.Errorf("%s must set a name", reportBundleMetadataFile)
+	}
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .sql files in %s", directory)
+	}
+	sort.Strings(files)
+
+	bundle := &reportBundle{
+		Name:        metadata.Name,
+		Description: metadata.Description,
+	}
+	hash := sha256.New()
+	hash.Write(metadataContent)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %s", file, err)
+		}
+
+		query := reportBundleQuery{
+			File:        filepath.Base(file),
+			Name:        strings.TrimSuffix(filepath.Base(file), ".sql"),
+			RawQuery:    string(content),
+			ContentHash: contentHash(string(content)),
+		}
+		if override, ok := metadata.Queries[query.File]; ok && override.Name != "" {
+			query.Name = override.Name
+		}
+		bundle.Queries = append(bundle.Queries, query)
+		fmt.Fprintf(hash, "\n%s:%s", query.File, query.ContentHash)
+	}
+
+	for file := range metadata.Queries {
+		if _, err := os.Stat(filepath.Join(directory, file)); err != nil {
+			return nil, fmt.Errorf("%s names the query of %s, which does not exist", reportBundleMetadataFile, file)
+		}
+	}
+
+	bundle.ContentHash = hex.EncodeToString(hash.Sum(nil))
+	return bundle, nil
+}
+
+// contentHash returns the hex encoded SHA-256 hash of content.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
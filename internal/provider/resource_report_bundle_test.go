@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func writeBundle(t *testing.T, files map[string]string) string {
+	directory := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %s: %s", name, err)
+		}
+	}
+	return directory
+}
+
+func TestLoadReportBundle(t *testing.T) {
+	directory := writeBundle(t, map[string]string{
+		"report.yaml":  "name: Revenue\ndescription: Monthly revenue\nqueries:\n  totals.sql:\n    name: Revenue totals\n",
+		"totals.sql":   "select 1",
+		"by_month.sql": "select 2",
+		"notes.txt":    "ignored",
+	})
+
+	bundle, err := loadReportBundle(directory)
+	if err != nil {
+		t.Fatalf("loadReportBundle: %s", err)
+	}
+	if bundle.Name != "Revenue" || bundle.Description != "Monthly revenue" {
+		t.Errorf("metadata = %q, %q", bundle.Name, bundle.Description)
+	}
+	if len(bundle.Queries) != 2 {
+		t.Fatalf("queries = %+v, want 2", bundle.Queries)
+	}
+	// Files are sorted, named after the file unless report.yaml names them
+	if bundle.Queries[0].File != "by_month.sql" || bundle.Queries[0].Name != "by_month" {
+		t.Errorf("first query = %+v", bundle.Queries[0])
+	}
+	if bundle.Queries[1].Name != "Revenue totals" || bundle.Queries[1].ContentHash != contentHash("select 1") {
+		t.Errorf("second query = %+v", bundle.Queries[1])
+	}
+
+	// The content hash changes with any file of the bundle
+	again, _ := loadReportBundle(directory)
+	if again.ContentHash != bundle.ContentHash {
+		t.Errorf("content hash is not stable")
+	}
+	if err := os.WriteFile(filepath.Join(directory, "totals.sql"), []byte("select 3"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changed, _ := loadReportBundle(directory)
+	if changed.ContentHash == bundle.ContentHash {
+		t.Errorf("content hash did not change with a query")
+	}
+}
+
+func TestLoadReportBundleErrors(t *testing.T) {
+	cases := map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"missing metadata": {map[string]string{"a.sql": "select 1"}, "unable to read report.yaml"},
+		"invalid metadata": {map[string]string{"report.yaml": "name: [", "a.sql": "select 1"}, "unable to parse report.yaml"},
+		"unnamed report":   {map[string]string{"report.yaml": "description: x", "a.sql": "select 1"}, "must set a name"},
+		"no queries":       {map[string]string{"report.yaml": "name: x"}, "no .sql files"},
+		"unknown query":    {map[string]string{"report.yaml": "name: x\nqueries:\n  b.sql:\n    name: B\n", "a.sql": "select 1"}, "b.sql, which does not exist"},
+	}
+	for name, c := range cases {
+		_, err := loadReportBundle(writeBundle(t, c.files))
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: error = %v, want %q", name, err, c.want)
+		}
+	}
+}
+
+func TestReportBundleMatchQueries(t *testing.T) {
+	bundle := &reportBundle{Queries: []reportBundleQuery{
+		{File: "totals.sql", Name: "Revenue totals"},
+		{File: "by_month.sql", Name: "by_month"},
+		{File: "new.sql", Name: "new"},
+	}}
+	query := func(token string, name string) ReportBundleQueryModel {
+		return ReportBundleQueryModel{QueryToken: types.StringValue(token), Name: types.StringValue(name)}
+	}
+
+	// Imported queries are keyed by token and matched by name. Queries without a file keep their key.
+	matched := bundle.matchQueries(map[string]ReportBundleQueryModel{
+		"totals.sql": query("q1", "Old name"),
+		"q2":         query("q2", "by_month"),
+		"q3":         query("q3", "Revenue totals"),
+		"q4":         query("q4", "gone"),
+	})
+
+	want := map[string]string{"totals.sql": "q1", "by_month.sql": "q2", "q3": "q3", "q4": "q4"}
+	if len(matched) != len(want) {
+		t.Fatalf("matchQueries = %v, want %v", matched, want)
+	}
+	for key, token := range want {
+		if matched[key].QueryToken.ValueString() != token {
+			t.Errorf("matchQueries[%s] = %s, want %s", key, matched[key].QueryToken.ValueString(), token)
+		}
+	}
+
+	// Two imported queries of the same name are matched once
+	matched = bundle.matchQueries(map[string]ReportBundleQueryModel{
+		"q5": query("q5", "new"),
+		"q6": query("q6", "new"),
+	})
+	if matched["new.sql"].QueryToken.ValueString() != "q5" || matched["q6"].QueryToken.ValueString() != "q6" {
+		t.Errorf("matchQueries with duplicate names = %v", matched)
+	}
+}
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReportCloneResource{}
+var _ resource.ResourceWithModifyPlan = &ReportCloneResource{}
+
+// NewReportCloneResource returns a new instance of ReportCloneResource.
+func NewReportCloneResource() resource.Resource {
+	return &ReportCloneResource{}
+}
+
+// ReportCloneResource defines the resource implementation.
+type ReportCloneResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ReportCloneResourceModel describes the resource data model.
+type ReportCloneResourceModel struct {
+	ReportToken           types.String `tfsdk:"report_token"`
+	SourceReportToken     types.String `tfsdk:"source_report_token"`
+	TargetCollectionToken types.String `tfsdk:"target_collection_token"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	Parameters            types.Map    `tfsdk:"parameters"`
+	ResyncTrigger         types.String `tfsdk:"resync_trigger"`
+}
+
+// Metadata sets the resource type name.
+func (r *ReportCloneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_clone"
+}
+
+// Schema defines the resource schema.
+func (r *ReportCloneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Copy of a template report. The report is cloned once, after which only the metadata of the clone is managed. " +
+			"Changing `source_report_token` or `resync_trigger` replaces the clone with a fresh copy. " +
+			"Import with `<report_token>/<source_report_token>`",
+
+		Attributes: map[string]schema.Attribute{
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the clone",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the template report",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_collection_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the collection the clone is stored in",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the clone",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the clone",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"parameters": schema.MapAttribute{
+				MarkdownDescription: "Parameter values of the clone overriding the defaults of the template",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"resync_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value that re-clones the template when changed, such as a version or hash of the template",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ReportCloneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *ReportCloneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create clones the template and applies the metadata of the clone.
+func (r *ReportCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportCloneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s/clone", r.modeHost, r.workspaceId, plan.SourceReportToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clone report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clone report, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData struct {
+		ReportToken string `json:"token"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.ReportToken = types.StringValue(responseData.ReportToken)
+
+	// The clone exists from here on, so it is tracked even if its metadata cannot be applied
+	if err := r.patch(ctx, plan, types.MapNull(types.StringType)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update cloned report, got error: %s", err))
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *ReportCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ReportCloneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, state.ReportToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData struct {
+			Name            string            `json:"name"`
+			Description     string            `json:"description"`
+			CollectionToken string            `json:"space_token"`
+			Parameters      map[string]string `json:"parameters"`
+		}
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.Description = types.StringValue(responseData.Description)
+		state.TargetCollectionToken = types.StringValue(responseData.CollectionToken)
+
+		// Only the overridden parameters are tracked, the other values come from the template
+		if !state.Parameters.IsNull() {
+			overrides := map[string]string{}
+			resp.Diagnostics.Append(state.Parameters.ElementsAs(ctx, &overrides, false)...)
+			for name := range overrides {
+				if value, ok := responseData.Parameters[name]; ok {
+					overrides[name] = value
+				} else {
+					delete(overrides, name)
+				}
+			}
+			parameters, diags := types.MapValueFrom(ctx, types.StringType, overrides)
+			resp.Diagnostics.Append(diags...)
+			state.Parameters = parameters
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource.
+func (r *ReportCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state ReportCloneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.patch(ctx, plan, state.Parameters); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update report, got error: %s", err))
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the clone. The template is left untouched.
+func (r *ReportCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state ReportCloneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, state.ReportToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || (httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete report, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState takes "<report_token>/<source_report_token>", as reports do not expose the template they were cloned from.
+func (r *ReportCloneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	reportToken, sourceReportToken, ok := strings.Cut(req.ID, "/")
+	if !ok || reportToken == "" || sourceReportToken == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Expected an import ID of the form <report_token>/<source_report_token>, got: %s", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("report_token"), reportToken)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_report_token"), sourceReportToken)...)
+}
+
+// patch applies the name, description, collection and parameter overrides to the clone. Overrides of
+// priorParameters that are no longer planned are sent as null, which resets them to the template default.
+func (r *ReportCloneResource) patch(ctx context.Context, plan ReportCloneResourceModel, priorParameters types.Map) error {
+	planned := map[string]string{}
+	if diags := plan.Parameters.ElementsAs(ctx, &planned, false); diags.HasError() {
+		return fmt.Errorf("unable to read parameters: %v", diags)
+	}
+	prior := map[string]string{}
+	if diags := priorParameters.ElementsAs(ctx, &prior, false); diags.HasError() {
+		return fmt.Errorf("unable to read parameters: %v", diags)
+	}
+
+	parameters := map[string]*string{}
+	for name := range prior {
+		parameters[name] = nil
+	}
+	for name, value := range planned {
+		parameters[name] = &value
+	}
+
+	payload := ReportPayload{
+		Report: Report{
+			Name:            plan.Name.ValueString(),
+			Description:     plan.Description.ValueString(),
+			CollectionToken: plan.TargetCollectionToken.ValueString(),
+			Parameters:      parameters,
+		},
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, plan.ReportToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
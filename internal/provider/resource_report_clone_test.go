@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testParameters(values map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for name, value := range values {
+		elements[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestReportClonePatchResetsRemovedParameters(t *testing.T) {
+	ctx := context.Background()
+	standIn := newModeStandIn(t, map[string]interface{}{
+		"PATCH /api/ws/reports/r1": map[string]string{"token": "r1"},
+	})
+	r := &ReportCloneResource{client: http.DefaultClient, modeHost: standIn.URL, workspaceId: "ws"}
+	plan := ReportCloneResourceModel{
+		ReportToken:           types.StringValue("r1"),
+		TargetCollectionToken: types.StringValue("c1"),
+		Name:                  types.StringValue("Revenue"),
+		Description:           types.StringValue(""),
+		Parameters:            testParameters(map[string]string{"region": "emea", "year": "2026"}),
+	}
+
+	// A parameter dropped from the plan is sent as null so that it falls back to the template default
+	if err := r.patch(ctx, plan, testParameters(map[string]string{"region": "us", "segment": "smb"})); err != nil {
+		t.Fatalf("patch: %s", err)
+	}
+	// Without prior parameters only the planned ones are sent
+	if err := r.patch(ctx, plan, types.MapNull(types.StringType)); err != nil {
+		t.Fatalf("patch: %s", err)
+	}
+	// Removing every parameter resets them all
+	plan.Parameters = types.MapNull(types.StringType)
+	if err := r.patch(ctx, plan, testParameters(map[string]string{"region": "emea"})); err != nil {
+		t.Fatalf("patch: %s", err)
+	}
+
+	want := []string{
+		`{"report":{"name":"Revenue","description":"","space_token":"c1","parameters":{"region":"emea","segment":null,"year":"2026"}}}`,
+		`{"report":{"name":"Revenue","description":"","space_token":"c1","parameters":{"region":"emea","year":"2026"}}}`,
+		`{"report":{"name":"Revenue","description":"","space_token":"c1","parameters":{"region":null}}}`,
+	}
+	bodies := standIn.bodies["PATCH /api/ws/reports/r1"]
+	if len(bodies) != len(want) {
+		t.Fatalf("received %d patches, want %d", len(bodies), len(want))
+	}
+	for i, body := range bodies {
+		if body != want[i] {
+			t.Errorf("patch %d = %s, want %s", i, body, want[i])
+		}
+	}
+
+	// Errors of the Mode API are returned
+	plan.ReportToken = types.StringValue("missing")
+	if err := r.patch(ctx, plan, types.MapNull(types.StringType)); err == nil {
+		t.Errorf("patch of a missing report succeeded")
+	}
+}
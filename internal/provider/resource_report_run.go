@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReportRunResource{}
+var _ resource.ResourceWithModifyPlan = &ReportRunResource{}
+
+// NewReportRunResource returns a new instance of ReportRunResource.
+func NewReportRunResource() resource.Resource {
+	return &ReportRunResource{}
+}
+
+// ReportRunResource defines the resource implementation.
+type ReportRunResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ReportRunResourceModel describes the resource data model.
+type ReportRunResourceModel struct {
+	ReportToken types.String `tfsdk:"report_token"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Parameters  types.Map    `tfsdk:"parameters"`
+	Timeout     types.String `tfsdk:"timeout"`
+	RunToken    types.String `tfsdk:"run_token"`
+	State       types.String `tfsdk:"state"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	CompletedAt types.String `tfsdk:"completed_at"`
+	QueryRuns   types.List   `tfsdk:"query_runs"`
+}
+
+type ReportRunPayload struct {
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+var queryRunAttrTypes = map[string]attr.Type{
+	"query_run_token": types.StringType,
+	"query_token":     types.StringType,
+	"state":           types.StringType,
+}
+
+// reportRunResponse is the subset of a report run returned by the API.
+type reportRunResponse struct {
+	RunToken    string `json:"token"`
+	State       string `json:"state"`
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at"`
+}
+
+// Metadata sets the resource type name.
+func (r *ReportRunResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_run"
+}
+
+// Schema defines the resource schema.
+func (r *ReportRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Triggers a run of a report and waits for it to finish. A new run is started whenever `triggers` or `parameters` change.",
+
+		Attributes: map[string]schema.Attribute{
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report to run",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that start a new run when changed",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				MarkdownDescription: "Report parameters to run the report with",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the run to finish, as a Go duration string such as `30m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+				Validators: []validator.String{
+					DurationValidator(),
+				},
+			},
+			"run_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report run",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the report run",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the run was started",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"completed_at": schema.StringAttribute{
+				MarkdownDescription: "Time the run finished",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"query_runs": schema.ListAttribute{
+				MarkdownDescription: "State of every query run belonging to the report run",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: queryRunAttrTypes,
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ReportRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *ReportRunResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create starts a new report run and waits for it to finish.
+func (r *ReportRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportRunResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(plan.Timeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Timeout", fmt.Sprintf("Unable to parse timeout %q: %s", plan.Timeout.ValueString(), err))
+		return
+	}
+
+	payload := ReportRunPayload{}
+	if !plan.Parameters.IsNull() {
+		resp.Diagnostics.Append(plan.Parameters.ElementsAs(ctx, &payload.Parameters, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s/runs", r.modeHost, r.workspaceId, plan.ReportToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create report run, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create report run, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusAccepted {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create report run, got error: %v", httpResp))
+		return
+	}
+
+	var responseData reportRunResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	run := &responseData
+	waitErr := PollWithBackoff(ctx, timeout, 5*time.Second, time.Minute, func() (bool, error) {
+		current, statusCode, err := r.readReportRun(ctx, plan.ReportToken.ValueString(), responseData.RunToken)
+		if err != nil {
+			return false, err
+		}
+		if statusCode != http.StatusOK {
+			return false, fmt.Errorf("received non-200 response status: %d", statusCode)
+		}
+		run = current
+		return reportRunFinished(run.State), nil
+	})
+
+	// The run exists even if waiting for it failed, so it is always recorded in state. An error
+	// below taints the resource and the next apply starts a new run.
+	resp.Diagnostics.Append(r.setReportRun(ctx, &plan, run)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Report Run Error", fmt.Sprintf("Report run %s did not finish: %s", run.RunToken, waitErr))
+		return
+	}
+	if run.State != "succeeded" && run.State != "completed" {
+		resp.Diagnostics.AddError("Report Run Error", fmt.Sprintf("Report run %s finished with state %q", run.RunToken, run.State))
+	}
+}
+
+// Read handles reading the resource.
+func (r *ReportRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ReportRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	run, statusCode, err := r.readReportRun(ctx, state.ReportToken.ValueString(), state.RunToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report run, got error: %s", err))
+		return
+	}
+
+	if statusCode == http.StatusOK {
+		resp.Diagnostics.Append(r.setReportRun(ctx, &state, run)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if statusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", statusCode))
+	}
+}
+
+// Update only stores the new timeout, every other argument forces a new run.
+func (r *ReportRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportRunResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the run from the state. Report runs cannot be deleted through the API.
+func (r *ReportRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// readReportRun fetches a report run and its query runs. A non-200 status code is returned without an error.
+func (r *ReportRunResource) readReportRun(ctx context.Context, reportToken string, runToken string) (*reportRunResponse, int, error) {
+	url := fmt.Sprintf("%s/api/%s/reports/%s/runs/%s", r.modeHost, r.workspaceId, reportToken, runToken)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, httpResp.StatusCode, nil
+	}
+
+	var responseData reportRunResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error parsing response: %s", err)
+	}
+
+	return &responseData, http.StatusOK, nil
+}
+
+// readQueryRuns lists the query runs of a report run.
+func (r *ReportRunResource) readQueryRuns(ctx context.Context, reportToken string, runToken string) ([]attr.Value, error) {
+	url := fmt.Sprintf("%s/api/%s/reports/%s/runs/%s/query_runs", r.modeHost, r.workspaceId, reportToken, runToken)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+
+	var responseData struct {
+		Embedded struct {
+			QueryRuns []struct {
+				QueryRunToken string `json:"token"`
+				QueryToken    string `json:"query_token"`
+				State         string `json:"state"`
+			} `json:"query_runs"`
+		} `json:"_embedded"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing response: %s", err)
+	}
+
+	queryRuns := []attr.Value{}
+	for _, queryRun := range responseData.Embedded.QueryRuns {
+		queryRuns = append(queryRuns, types.ObjectValueMust(queryRunAttrTypes, map[string]attr.Value{
+			"query_run_token": types.StringValue(queryRun.QueryRunToken),
+			"query_token":     types.StringValue(queryRun.QueryToken),
+			"state":           types.StringValue(queryRun.State),
+		}))
+	}
+
+	return queryRuns, nil
+}
+
+// setReportRun copies a report run and its query runs into the model.
+func (r *ReportRunResource) setReportRun(ctx context.Context, model *ReportRunResourceModel, run *reportRunResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.RunToken = types.StringValue(run.RunToken)
+	model.State = types.StringValue(run.State)
+	model.CreatedAt = types.StringValue(run.CreatedAt)
+	model.CompletedAt = types.StringValue(run.CompletedAt)
+
+	queryRuns, err := r.readQueryRuns(ctx, model.ReportToken.ValueString(), run.RunToken)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read query runs, got error: %s", err))
+		queryRuns = []attr.Value{}
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: queryRunAttrTypes}, queryRuns)
+	diags.Append(listDiags...)
+	model.QueryRuns = list
+
+	return diags
+}
+
+// reportRunFinished reports whether a run state is terminal.
+func reportRunFinished(state string) bool {
+	switch state {
+	case "succeeded", "completed", "failed", "cancelled":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReportSharingResource{}
+var _ resource.ResourceWithModifyPlan = &ReportSharingResource{}
+
+// NewReportSharingResource returns a new instance of ReportSharingResource.
+func NewReportSharingResource() resource.Resource {
+	return &ReportSharingResource{}
+}
+
+// ReportSharingResource defines the resource implementation.
+type ReportSharingResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ReportSharingResourceModel describes the resource data model.
+type ReportSharingResourceModel struct {
+	ReportToken            types.String `tfsdk:"report_token"`
+	PublicLinkEnabled      types.Bool   `tfsdk:"public_link_enabled"`
+	ExternalSharingEnabled types.Bool   `tfsdk:"external_sharing_enabled"`
+	EmbedAllowed           types.Bool   `tfsdk:"embed_allowed"`
+	ViewableByEveryone     types.Bool   `tfsdk:"viewable_by_everyone"`
+}
+
+type ReportSharing struct {
+	PublicLinkEnabled      bool `json:"public_link_enabled"`
+	ExternalSharingEnabled bool `json:"external_sharing_enabled"`
+	EmbedAllowed           bool `json:"embed_allowed"`
+	ViewableByEveryone     bool `json:"viewable_by_everyone"`
+}
+
+type ReportSharingPayload struct {
+	Report ReportSharing `json:"report"`
+}
+
+// Metadata sets the resource type name.
+func (r *ReportSharingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_sharing"
+}
+
+// Schema defines the resource schema.
+func (r *ReportSharingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages how a report can be shared. Unset settings are disabled, and destroying the resource disables all of them.",
+
+		Attributes: map[string]schema.Attribute{
+			"report_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the report",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_link_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Allow anyone with the link to view the report without signing in",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"external_sharing_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Allow the report to be shared with people outside of the workspace",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"embed_allowed": schema.BoolAttribute{
+				MarkdownDescription: "Allow the report to be embedded in other sites",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"viewable_by_everyone": schema.BoolAttribute{
+				MarkdownDescription: "Make the report viewable by every member of the workspace",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ReportSharingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *ReportSharingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create handles the creation of the resource.
+func (r *ReportSharingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportSharingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSharing(ctx, plan.ReportToken.ValueString(), plan.sharing()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set report sharing, got error: %s", err))
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *ReportSharingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ReportSharingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, state.ReportToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read report, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData ReportSharing
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+
+		state.PublicLinkEnabled = types.BoolValue(responseData.PublicLinkEnabled)
+		state.ExternalSharingEnabled = types.BoolValue(responseData.ExternalSharingEnabled)
+		state.EmbedAllowed = types.BoolValue(responseData.EmbedAllowed)
+		state.ViewableByEveryone = types.BoolValue(responseData.ViewableByEveryone)
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource.
+func (r *ReportSharingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ReportSharingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSharing(ctx, plan.ReportToken.ValueString(), plan.sharing()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set report sharing, got error: %s", err))
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete disables every sharing setting of the report.
+func (r *ReportSharingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state ReportSharingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A deleted report has nothing left to share
+	err := r.setSharing(ctx, state.ReportToken.ValueString(), ReportSharing{})
+	if err != nil && err != errReportNotFound {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset report sharing, got error: %s", err))
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ReportSharingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("report_token"), req.ID)...)
+}
+
+// sharing returns the sharing settings of the model.
+func (m ReportSharingResourceModel) sharing() ReportSharing {
+	return ReportSharing{
+		PublicLinkEnabled:      m.PublicLinkEnabled.ValueBool(),
+		ExternalSharingEnabled: m.ExternalSharingEnabled.ValueBool(),
+		EmbedAllowed:           m.EmbedAllowed.ValueBool(),
+		ViewableByEveryone:     m.ViewableByEveryone.ValueBool(),
+	}
+}
+
+// errReportNotFound is returned by setSharing and setTheme when the report does not exist.
+var errReportNotFound = fmt.Errorf("report not found")
+
+// setSharing applies sharing settings to a report.
+func (r *ReportSharingResource) setSharing(ctx context.Context, reportToken string, sharing ReportSharing) error {
+	url := fmt.Sprintf("%s/api/%s/reports/%s", r.modeHost, r.workspaceId, reportToken)
+	jsonBody, _ := json.Marshal(ReportSharingPayload{Report: sharing})
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return errReportNotFound
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received non-200 response status: %d", httpResp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ThemeResource{}
+var _ resource.ResourceWithModifyPlan = &ThemeResource{}
+
+// NewThemeResource returns a new instance of ThemeResource.
+func NewThemeResource() resource.Resource {
+	return &ThemeResource{}
+}
+
+// ThemeResource defines the resource implementation.
+type ThemeResource struct {
+	client      *http.Client
+	modeHost    string
+	workspaceId string
+	readOnly    bool
+}
+
+// ThemeResourceModel describes the resource data model.
+type ThemeResourceModel struct {
+	ThemeToken   types.String `tfsdk:"theme_token"`
+	Name         types.String `tfsdk:"name"`
+	Css          types.String `tfsdk:"css"`
+	FontFamily   types.String `tfsdk:"font_family"`
+	ColorPalette types.List   `tfsdk:"color_palette"`
+	State        types.String `tfsdk:"state"`
+}
+
+type Theme struct {
+	Name         string   `json:"name"`
+	Css          string   `json:"css"`
+	FontFamily   string   `json:"font_family"`
+	ColorPalette []string `json:"color_palette"`
+}
+
+type ThemePayload struct {
+	Theme Theme `json:"theme"`
+}
+
+// themeResponse is the theme representation returned by the API.
+type themeResponse struct {
+	ThemeToken   string   `json:"token"`
+	Name         string   `json:"name"`
+	Css          string   `json:"css"`
+	FontFamily   string   `json:"font_family"`
+	ColorPalette []string `json:"color_palette"`
+	State        string   `json:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *ThemeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_theme"
+}
+
+// Schema defines the resource schema.
+func (r *ThemeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Workspace theme used to brand reports. Apply it to a report with `modeanalytics_report_theme`.",
+
+		Attributes: map[string]schema.Attribute{
+			"theme_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the theme",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the theme",
+				Required:            true,
+			},
+			"css": schema.StringAttribute{
+				MarkdownDescription: "Custom CSS of the theme, typically read with `file()`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"font_family": schema.StringAttribute{
+				MarkdownDescription: "Font family used by reports with the theme",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"color_palette": schema.ListAttribute{
+				MarkdownDescription: "Colors used by charts, as hex codes",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the theme",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure sets the resource client.
+func (r *ThemeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(struct {
+		Client      *http.Client
+		ModeHost    string
+		WorkspaceId string
+		ReadOnly    bool
+		Cache       *ReadCache
+		Policy      *GovernancePolicy
+		Scim        *ScimClient
+	})
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected struct with *http.Client, ModeHost, and WorkspaceId, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = config.Client
+	r.modeHost = config.ModeHost
+	r.workspaceId = config.WorkspaceId
+	r.readOnly = config.ReadOnly
+}
+
+// ModifyPlan rejects plans that read_only does not allow.
+func (r *ThemeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	CheckWritePlan(ctx, r.readOnly, req, resp)
+}
+
+// Create handles the creation of the resource.
+func (r *ThemeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ThemeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload := ThemePayload{
+		Theme: Theme{
+			Name:         plan.Name.ValueString(),
+			Css:          plan.Css.ValueString(),
+			FontFamily:   plan.FontFamily.ValueString(),
+			ColorPalette: []string{},
+		},
+	}
+	if !plan.ColorPalette.IsNull() {
+		resp.Diagnostics.Append(plan.ColorPalette.ElementsAs(ctx, &payload.Theme.ColorPalette, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/themes", r.modeHost, r.workspaceId)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create theme, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create theme, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData themeResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.ThemeToken = types.StringValue(responseData.ThemeToken)
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource.
+func (r *ThemeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ThemeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/themes/%s", r.modeHost, r.workspaceId, state.ThemeToken.ValueString())
+	httpReq, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read theme, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read theme, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusOK {
+		var responseData themeResponse
+		err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+			return
+		}
+		if responseData.State == "soft_deleted" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		state.Name = types.StringValue(responseData.Name)
+		state.Css = types.StringValue(responseData.Css)
+		state.FontFamily = types.StringValue(responseData.FontFamily)
+		state.State = types.StringValue(responseData.State)
+
+		// Keep an unset palette null unless the theme was given colors in the UI
+		if !state.ColorPalette.IsNull() || len(responseData.ColorPalette) > 0 {
+			colorPalette, diags := types.ListValueFrom(ctx, types.StringType, responseData.ColorPalette)
+			resp.Diagnostics.Append(diags...)
+			state.ColorPalette = colorPalette
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	} else if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+	} else {
+		resp.Diagnostics.AddError("API response error", fmt.Sprintf("Received non-200 response status: %d", httpResp.StatusCode))
+	}
+}
+
+// Update handles updating the resource.
+func (r *ThemeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ThemeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload := ThemePayload{
+		Theme: Theme{
+			Name:         plan.Name.ValueString(),
+			Css:          plan.Css.ValueString(),
+			FontFamily:   plan.FontFamily.ValueString(),
+			ColorPalette: []string{},
+		},
+	}
+	if !plan.ColorPalette.IsNull() {
+		resp.Diagnostics.Append(plan.ColorPalette.ElementsAs(ctx, &payload.Theme.ColorPalette, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	jsonBody, _ := json.Marshal(payload)
+
+	url := fmt.Sprintf("%s/api/%s/themes/%s", r.modeHost, r.workspaceId, plan.ThemeToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update theme, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update theme, got error: %s", url))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var responseData themeResponse
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error parsing response: %s", err))
+		return
+	}
+
+	plan.State = types.StringValue(responseData.State)
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource.
+func (r *ThemeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if CheckReadOnly(r.readOnly, &resp.Diagnostics) {
+		return
+	}
+
+	var state ThemeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/%s/themes/%s", r.modeHost, r.workspaceId, state.ThemeToken.ValueString())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete theme, got error: %s", err))
+		return
+	}
+
+	httpResp, err := HttpRetry(r.client, httpReq)
+	if err != nil || httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete theme, got error: %v", httpResp))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	// Verify deletion of the resource
+	deletionErr := CheckDeletion(url, r.client)
+	if deletionErr != nil {
+		resp.Diagnostics.AddError("Theme Deletion Error", fmt.Sprintf("Failed to verify deletion: %s", deletionErr))
+		return
+	}
+
+	// Remove the resource from the state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ThemeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("theme_token"), req.ID)...)
+}
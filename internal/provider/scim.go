@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	scimGroupSchema   = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimPatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// ScimClient talks to the SCIM 2.0 API used for identity provider provisioning. Under the scim
+// user management backend, groups and group memberships are managed through it instead of the REST API.
+type ScimClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+// ScimGroup is a SCIM group. Members only carry the id of the user.
+type ScimGroup struct {
+	Schemas     []string     `json:"schemas,omitempty"`
+	Id          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []ScimMember `json:"members,omitempty"`
+}
+
+type ScimMember struct {
+	Value string `json:"value"`
+}
+
+// ScimPatchOperation is one operation of a SCIM PATCH request.
+type ScimPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// NewScimClient returns a client for the SCIM API at baseURL, authenticated with a bearer token.
+func NewScimClient(baseURL string, token string) *ScimClient {
+	return &ScimClient{
+		client: &http.Client{
+			Transport: &scimTransport{
+				token:               token,
+				underlyingTransport: http.DefaultTransport,
+			},
+		},
+		baseURL: baseURL,
+	}
+}
+
+// CreateGroup creates a group without members.
+func (c *ScimClient) CreateGroup(ctx context.Context, displayName string) (*ScimGroup, error) {
+	group := ScimGroup{
+		Schemas:     []string{scimGroupSchema},
+		DisplayName: displayName,
+	}
+	var created ScimGroup
+	if err := c.do(ctx, http.MethodPost, c.baseURL+"/Groups", group, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetGroup reads a group. It returns nil if the group does not exist.
+func (c *ScimClient) GetGroup(ctx context.Context, id string) (*ScimGroup, error) {
+	var group ScimGroup
+	err := c.do(ctx, http.MethodGet, c.baseURL+"/Groups/"+url.PathEscape(id), nil, &group)
+	if err == errScimNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// PatchGroup applies operations to a group.
+func (c *ScimClient) PatchGroup(ctx context.Context, id string, operations ...ScimPatchOperation) error {
+	patch := scimPatchRequest{
+		Schemas:    []string{scimPatchOpSchema},
+		Operations: operations,
+	}
+	return c.do(ctx, http.MethodPatch, c.baseURL+"/Groups/"+url.PathEscape(id), patch, nil)
+}
+
+// DeleteGroup deletes a group. A group that does not exist counts as deleted.
+func (c *ScimClient) DeleteGroup(ctx context.Context, id string) error {
+	err := c.do(ctx, http.MethodDelete, c.baseURL+"/Groups/"+url.PathEscape(id), nil, nil)
+	if err == errScimNotFound {
+		return nil
+	}
+	return err
+}
+
+// FindUserId returns the id of the user with the given userName.
+func (c *ScimClient) FindUserId(ctx context.Context, userName string) (string, error) {
+	filter := url.QueryEscape(fmt.Sprintf("userName eq %q", userName))
+	var list struct {
+		Resources []struct {
+			Id       string `json:"id"`
+			UserName string `json:"userName"`
+		} `json:"Resources"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.baseURL+"/Users?filter="+filter, nil, &list); err != nil {
+		return "", err
+	}
+
+	for _, user := range list.Resources {
+		if user.UserName == userName {
+			return user.Id, nil
+		}
+	}
+	return "", fmt.Errorf("no user with the userName %q", userName)
+}
+
+// AddMemberOperation returns the operation adding a user to a group.
+func AddMemberOperation(userId string) ScimPatchOperation {
+	return ScimPatchOperation{Op: "add", Path: "members", Value: []ScimMember{{Value: userId}}}
+}
+
+// RemoveMemberOperation returns the operation removing a user from a group.
+func RemoveMemberOperation(userId string) ScimPatchOperation {
+	return ScimPatchOperation{Op: "remove", Path: fmt.Sprintf("members[value eq %q]", userId)}
+}
+
+// HasMember reports whether the user is a member of the group.
+func (g *ScimGroup) HasMember(userId string) bool {
+	for _, member := range g.Members {
+		if member.Value == userId {
+			return true
+		}
+	}
+	return false
+}
+
+var errScimNotFound = fmt.Errorf("SCIM resource not found")
+
+// AddScimStateError reports a group missing from the SCIM API. Existing state may still hold the REST token
+// of the group from before the switch to the scim backend, so the resource is kept rather than planned for
+// a re-creation that would duplicate the group.
+func AddScimStateError(diags *diag.Diagnostics, kind string, id string) {
+	diags.AddError(
+		"SCIM Group Not Found",
+		fmt.Sprintf("The %s %q was not found through SCIM. If it was created with user_management_backend = rest, "+
+			"its state holds REST tokens: run terraform state rm and import it again with its SCIM id. "+
+			"If it was deleted outside of Terraform, run terraform state rm to create it again.", kind, id),
+	)
+}
+
+// do sends a SCIM request. SCIM answers with 201 on create and 204 on empty responses,
+// so every 2xx status is a success. A 404 yields errScimNotFound.
+func (c *ScimClient) do(ctx context.Context, method string, endpoint string, body interface{}, target interface{}) error {
+	var jsonBody []byte
+	if body != nil {
+		jsonBody, _ = json.Marshal(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := HttpRetry(c.client, httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return errScimNotFound
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		var scimError struct {
+			Detail string `json:"detail"`
+		}
+		_ = json.NewDecoder(httpResp.Body).Decode(&scimError)
+		if scimError.Detail != "" {
+			return fmt.Errorf("received non-2xx response status: %d: %s", httpResp.StatusCode, scimError.Detail)
+		}
+		return fmt.Errorf("received non-2xx response status: %d", httpResp.StatusCode)
+	}
+
+	if target == nil || httpResp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(target); err != nil {
+		return fmt.Errorf("error parsing response: %s", err)
+	}
+	return nil
+}
+
+// Custom transport to add the SCIM bearer token and content type.
+type scimTransport struct {
+	token               string
+	underlyingTransport http.RoundTripper
+}
+
+func (t *scimTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	req.Header.Set("Content-Type", "application/scim+json")
+	req.Header.Set("Accept", "application/scim+json")
+	return t.underlyingTransport.RoundTrip(req)
+}
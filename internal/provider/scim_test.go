@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// scimStandIn is an in-memory SCIM server holding groups and users. It records the
+// PATCH bodies it receives, so that tests can check what the client sends.
+type scimStandIn struct {
+	t       *testing.T
+	mu      sync.Mutex
+	groups  map[string]*ScimGroup
+	users   map[string]string
+	patches []scimPatchRequest
+	nextId  int
+}
+
+func newScimStandIn(t *testing.T) (*scimStandIn, *ScimClient) {
+	standIn := &scimStandIn{t: t, groups: map[string]*ScimGroup{}, users: map[string]string{}}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	return standIn, NewScimClient(server.URL+"/scim/v2", "secret")
+}
+
+func (s *scimStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		s.t.Errorf("Authorization header = %q, want %q", got, "Bearer secret")
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/scim+json" {
+		s.t.Errorf("Content-Type header = %q, want application/scim+json", got)
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/scim/v2")
+	switch {
+	case req.Method == http.MethodPost && path == "/Groups":
+		var group ScimGroup
+		if err := json.NewDecoder(req.Body).Decode(&group); err != nil {
+			s.t.Fatalf("decoding group: %s", err)
+		}
+		if !reflect.DeepEqual(group.Schemas, []string{scimGroupSchema}) {
+			s.t.Errorf("group schemas = %v", group.Schemas)
+		}
+		s.nextId++
+		group.Id = fmt.Sprintf("group-%d", s.nextId)
+		s.groups[group.Id] = &group
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(group)
+
+	case req.Method == http.MethodGet && path == "/Users":
+		var resources []map[string]string
+		for userName, id := range s.users {
+			if req.URL.Query().Get("filter") == fmt.Sprintf("userName eq %q", userName) {
+				resources = append(resources, map[string]string{"id": id, "userName": userName})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"Resources": resources})
+
+	case strings.HasPrefix(path, "/Groups/"):
+		group, ok := s.groups[strings.TrimPrefix(path, "/Groups/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(group)
+		case http.MethodDelete:
+			delete(s.groups, group.Id)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPatch:
+			var patch scimPatchRequest
+			if err := json.NewDecoder(req.Body).Decode(&patch); err != nil {
+				s.t.Fatalf("decoding patch: %s", err)
+			}
+			s.patches = append(s.patches, patch)
+			if status := s.apply(group, patch); status != http.StatusNoContent {
+				w.WriteHeader(status)
+				_ = json.NewEncoder(w).Encode(map[string]string{"detail": "unsupported operation"})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// apply applies the operations of patch to group, as far as the client uses them.
+func (s *scimStandIn) apply(group *ScimGroup, patch scimPatchRequest) int {
+	for _, operation := range patch.Operations {
+		switch {
+		case operation.Op == "replace" && operation.Path == "displayName":
+			group.DisplayName = operation.Value.(string)
+		case operation.Op == "add" && operation.Path == "members":
+			for _, member := range operation.Value.([]interface{}) {
+				group.Members = append(group.Members, ScimMember{Value: member.(map[string]interface{})["value"].(string)})
+			}
+		case operation.Op == "remove" && strings.HasPrefix(operation.Path, "members[value eq "):
+			var userId string
+			fmt.Sscanf(strings.TrimPrefix(operation.Path, "members[value eq "), "%q", &userId)
+			members := []ScimMember{}
+			for _, member := range group.Members {
+				if member.Value != userId {
+					members = append(members, member)
+				}
+			}
+			group.Members = members
+		default:
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusNoContent
+}
+
+func TestScimClientGroupLifecycle(t *testing.T) {
+	ctx := context.Background()
+	standIn, client := newScimStandIn(t)
+	standIn.users["ada"] = "user-1"
+
+	group, err := client.CreateGroup(ctx, "Analysts")
+	if err != nil {
+		t.Fatalf("CreateGroup: %s", err)
+	}
+	if group.Id == "" || group.DisplayName != "Analysts" {
+		t.Fatalf("CreateGroup returned %+v", group)
+	}
+
+	userId, err := client.FindUserId(ctx, "ada")
+	if err != nil {
+		t.Fatalf("FindUserId: %s", err)
+	}
+	if userId != "user-1" {
+		t.Fatalf("FindUserId = %q, want user-1", userId)
+	}
+
+	if err := client.PatchGroup(ctx, group.Id, AddMemberOperation(userId)); err != nil {
+		t.Fatalf("PatchGroup add: %s", err)
+	}
+	read, err := client.GetGroup(ctx, group.Id)
+	if err != nil {
+		t.Fatalf("GetGroup: %s", err)
+	}
+	if !read.HasMember(userId) {
+		t.Fatalf("group members = %v, want %s", read.Members, userId)
+	}
+
+	if err := client.PatchGroup(ctx, group.Id, RemoveMemberOperation(userId)); err != nil {
+		t.Fatalf("PatchGroup remove: %s", err)
+	}
+	read, err = client.GetGroup(ctx, group.Id)
+	if err != nil {
+		t.Fatalf("GetGroup: %s", err)
+	}
+	if read.HasMember(userId) {
+		t.Fatalf("group members = %v, want none", read.Members)
+	}
+
+	if err := client.DeleteGroup(ctx, group.Id); err != nil {
+		t.Fatalf("DeleteGroup: %s", err)
+	}
+	read, err = client.GetGroup(ctx, group.Id)
+	if err != nil || read != nil {
+		t.Fatalf("GetGroup after delete = %+v, %v, want nil, nil", read, err)
+	}
+	if err := client.DeleteGroup(ctx, group.Id); err != nil {
+		t.Fatalf("DeleteGroup of a missing group: %s", err)
+	}
+}
+
+func TestScimClientPatchBodies(t *testing.T) {
+	ctx := context.Background()
+	standIn, client := newScimStandIn(t)
+
+	group, err := client.CreateGroup(ctx, "Analysts")
+	if err != nil {
+		t.Fatalf("CreateGroup: %s", err)
+	}
+	if err := client.PatchGroup(ctx, group.Id, AddMemberOperation("user-1")); err != nil {
+		t.Fatalf("PatchGroup add: %s", err)
+	}
+	if err := client.PatchGroup(ctx, group.Id, RemoveMemberOperation("user-1")); err != nil {
+		t.Fatalf("PatchGroup remove: %s", err)
+	}
+	if err := client.PatchGroup(ctx, group.Id, ScimPatchOperation{Op: "replace", Path: "displayName", Value: "Engineers"}); err != nil {
+		t.Fatalf("PatchGroup rename: %s", err)
+	}
+
+	want := []string{
+		`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"user-1"}]}]}`,
+		`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"remove","path":"members[value eq \"user-1\"]"}]}`,
+		`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","path":"displayName","value":"Engineers"}]}`,
+	}
+	if len(standIn.patches) != len(want) {
+		t.Fatalf("received %d patches, want %d", len(standIn.patches), len(want))
+	}
+	for i, patch := range standIn.patches {
+		got, _ := json.Marshal(patch)
+		if string(got) != want[i] {
+			t.Errorf("patch %d = %s, want %s", i, got, want[i])
+		}
+	}
+	if standIn.groups[group.Id].DisplayName != "Engineers" {
+		t.Errorf("displayName = %q, want Engineers", standIn.groups[group.Id].DisplayName)
+	}
+}
+
+func TestScimClientErrors(t *testing.T) {
+	ctx := context.Background()
+	_, client := newScimStandIn(t)
+
+	group, err := client.CreateGroup(ctx, "Analysts")
+	if err != nil {
+		t.Fatalf("CreateGroup: %s", err)
+	}
+
+	err = client.PatchGroup(ctx, group.Id, ScimPatchOperation{Op: "move"})
+	if err == nil || !strings.Contains(err.Error(), "400: unsupported operation") {
+		t.Errorf("PatchGroup with an unsupported operation = %v, want the SCIM error detail", err)
+	}
+
+	if err := client.PatchGroup(ctx, "missing", AddMemberOperation("user-1")); err != errScimNotFound {
+		t.Errorf("PatchGroup of a missing group = %v, want errScimNotFound", err)
+	}
+
+	if _, err := client.FindUserId(ctx, "nobody"); err == nil {
+		t.Errorf("FindUserId of an unknown user succeeded")
+	}
+}